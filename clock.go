@@ -0,0 +1,49 @@
+package promptui
+
+import (
+	"sync"
+	"time"
+)
+
+// clock tells the time to the features depending on it, like AutoSubmitDefault or ChangeDebounce, so the tests
+// can control it instead of sleeping.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the clock used by the prompts unless the tests set another one.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// timeSource returns the clock of the prompt.
+func (p *Prompt) timeSource() clock {
+	if p.clock == nil {
+		return systemClock{}
+	}
+	return p.clock
+}
+
+// afterFunc calls fn in its own goroutine once d has elapsed on c, unless the returned function is called first.
+func afterFunc(c clock, d time.Duration, fn func()) (cancel func()) {
+	canceled := make(chan struct{})
+	go func() {
+		select {
+		case <-c.After(d):
+			fn()
+		case <-canceled:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(canceled) })
+	}
+}
@@ -0,0 +1,133 @@
+package promptui
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock whose time only moves with Advance.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), c: ch})
+	return ch
+}
+
+// Advance moves the time forward by d, firing the waiters due by then.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.c <- w.at
+	}
+	c.waiters = pending
+}
+
+// wait blocks until n calls to After are pending, started by other goroutines.
+func (c *fakeClock) wait(t *testing.T, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		pending := len(c.waiters)
+		c.mu.Unlock()
+
+		if pending >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d timers to be pending", n)
+}
+
+func TestAfterFunc(t *testing.T) {
+	c := newFakeClock()
+	called := make(chan struct{})
+
+	cancel := afterFunc(c, time.Second, func() { t.Error("expected the canceled call to be skipped") })
+	c.wait(t, 1)
+	cancel()
+	cancel()
+
+	afterFunc(c, 2*time.Second, func() { close(called) })
+	c.wait(t, 2)
+	c.Advance(2 * time.Second)
+
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		t.Fatal("expected the function to be called once the delay elapsed")
+	}
+}
+
+func TestPromptClockAutoSubmit(t *testing.T) {
+	stdin, w := io.Pipe()
+	defer w.Close()
+
+	c := newFakeClock()
+	p := Prompt{
+		Label:             "Region",
+		Default:           "eu",
+		AutoSubmitDefault: time.Hour,
+		Stdin:             stdin,
+		Stdout:            &bytes.Buffer{},
+		clock:             c,
+	}
+
+	done := make(chan string)
+	go func() {
+		value, _ := p.Run()
+		done <- value
+	}()
+
+	// the countdown and the submission.
+	c.wait(t, 2)
+	c.Advance(time.Hour)
+
+	select {
+	case value := <-done:
+		if value != "eu" {
+			t.Errorf("expected the default to be submitted, got %q", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the default to be submitted without waiting an hour")
+	}
+}
@@ -2,6 +2,7 @@ package promptui
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"text/template"
@@ -55,15 +56,22 @@ const (
 var ResetCode = fmt.Sprintf("%s%dm", esc, reset)
 
 const (
-	hideCursor = esc + "?25l"
-	showCursor = esc + "?25h"
-	clearLine  = esc + "2K"
+	hideCursor      = esc + "?25l"
+	showCursor      = esc + "?25h"
+	clearLine       = esc + "2K"
+	clearScreenDown = esc + "J"
 )
 
 // FuncMap defines template helpers for the output. It can be extended as a regular map.
 //
 // The functions inside the map link the state, color and background colors strings detected in templates to a Styler
-// function that applies the given style using the corresponding constant.
+// function that applies the given style using the corresponding constant. The semantic helpers primary, success,
+// warning, danger and muted apply the styles set with SetSemanticColor instead, so that templates using them can be
+// themed without being edited.
+//
+// The progressbar helper takes a fraction between 0 and 1 and a width, rendering a bar of width blocks like
+// "▰▰▰▱▱", or "###--" when the terminal doesn't support Unicode. With a ValidateData function returning the
+// strength of the input as a float64, `{{ progressbar (data "strength") 10 }}` displays a live meter.
 var FuncMap = template.FuncMap{
 	"black":     Styler(FGBlack),
 	"red":       Styler(FGRed),
@@ -85,6 +93,53 @@ var FuncMap = template.FuncMap{
 	"faint":     Styler(FGFaint),
 	"italic":    Styler(FGItalic),
 	"underline": Styler(FGUnderline),
+	"primary":   semanticStyler("primary"),
+	"success":   semanticStyler("success"),
+	"warning":   semanticStyler("warning"),
+	"danger":    semanticStyler("danger"),
+	"muted":     semanticStyler("muted"),
+
+	"progressbar": progressBar,
+}
+
+// semanticColors holds the styles applied by the semantic helpers of FuncMap.
+var semanticColors = map[string]func(interface{}) string{
+	"primary": Styler(FGCyan),
+	"success": Styler(FGGreen),
+	"warning": Styler(FGYellow),
+	"danger":  Styler(FGRed),
+	"muted":   Styler(FGFaint),
+}
+
+// SetSemanticColor changes the style applied by one of the semantic template helpers: primary, success, warning,
+// danger or muted. Other names are ignored. It should be called before running prompts, for example when the
+// application starts.
+//
+//	promptui.SetSemanticColor("primary", promptui.FGMagenta, promptui.FGBold)
+func SetSemanticColor(name string, attrs ...attribute) {
+	if _, ok := semanticColors[name]; ok {
+		semanticColors[name] = Styler(attrs...)
+	}
+}
+
+func semanticStyler(name string) func(interface{}) string {
+	return func(v interface{}) string {
+		return semanticColors[name](v)
+	}
+}
+
+// progressBar renders width blocks, the given fraction of them filled. The fraction is clamped between 0 and 1.
+func progressBar(fraction float64, width int) string {
+	filled, empty := "▰", "▱"
+	if !supportsUnicode() {
+		filled, empty = "#", "-"
+	}
+
+	n := int(math.Round(math.Max(0, math.Min(1, fraction)) * float64(width)))
+	if width < 0 {
+		n, width = 0, 0
+	}
+	return strings.Repeat(filled, n) + strings.Repeat(empty, width-n)
 }
 
 func upLine(n uint) string {
@@ -1,6 +1,10 @@
 package promptui
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
 
 func TestStyler(t *testing.T) {
 	t.Run("renders a single code", func(t *testing.T) {
@@ -28,3 +32,51 @@ func TestStyler(t *testing.T) {
 		}
 	})
 }
+
+func TestSemanticColors(t *testing.T) {
+	defer SetSemanticColor("primary", FGCyan)
+
+	primary := FuncMap["primary"].(func(interface{}) string)
+
+	if s, expected := primary("hi"), Styler(FGCyan)("hi"); s != expected {
+		t.Errorf("style did not match: %s != %s", s, expected)
+	}
+
+	SetSemanticColor("primary", FGMagenta, FGBold)
+
+	if s, expected := primary("hi"), Styler(FGMagenta, FGBold)("hi"); s != expected {
+		t.Errorf("style did not match after remapping: %s != %s", s, expected)
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	defer func(icons IconSet) { Icons = icons }(Icons)
+
+	Icons = IconsUnicode
+	cases := []struct {
+		fraction float64
+		width    int
+		bar      string
+	}{
+		{0.6, 5, "▰▰▰▱▱"},
+		{0, 3, "▱▱▱"},
+		{1.5, 3, "▰▰▰"},
+		{-1, 2, "▱▱"},
+		{0.5, 0, ""},
+	}
+	for _, c := range cases {
+		if bar := progressBar(c.fraction, c.width); bar != c.bar {
+			t.Errorf("expected %q for %v of %d, got %q", c.bar, c.fraction, c.width, bar)
+		}
+	}
+
+	Icons = IconsASCII
+	tpl := template.Must(template.New("").Funcs(FuncMap).Parse(`{{ progressbar .Strength 4 }}`))
+	var out strings.Builder
+	if err := tpl.Execute(&out, struct{ Strength float64 }{0.5}); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "##--" {
+		t.Errorf("expected the ASCII bar from the template, got %q", out.String())
+	}
+}
@@ -37,6 +37,16 @@ var (
 	PipeCursor Pointer = pipeCursor
 )
 
+// GlyphCursor returns a Pointer displaying glyph styled with attrs in place of the character under the cursor,
+// for a visible caret when the terminal cursor is hidden. The glyph replaces the character rather than being
+// inserted before it, so the rest of the input stays in place. It should use a single column.
+func GlyphCursor(glyph rune, attrs ...attribute) Pointer {
+	style := Styler(attrs...)
+	return func(to []rune) []rune {
+		return []rune(style(string(glyph)))
+	}
+}
+
 // Cursor tracks the state associated with the movable cursor
 // The strategy is to keep the prompt, input pristine except for requested
 // modifications. The insertion of the cursor happens during a `format` call
@@ -50,10 +60,18 @@ type Cursor struct {
 	// Put the cursor before this slice
 	Position int
 	erase    bool
+	// keep the erasable default when Backspace is the first key pressed
+	keepOnBackspace bool
 }
 
 // NewCursor create a new cursor, with the DefaultCursor, the specified input,
 // and position at the end of the specified starting input.
+//
+// When eraseDefault is set, the starting input is a default value that the first key press can erase and the
+// cursor is placed at the start of the input. The first key then decides what happens to the default:
+//   - a printable character replaces the default with itself
+//   - Backspace clears the default
+//   - the Forward and Backward keys keep the default and start editing it
 func NewCursor(startinginput string, pointer Pointer, eraseDefault bool) Cursor {
 	if pointer == nil {
 		pointer = defaultCursor
@@ -98,23 +116,80 @@ func (c *Cursor) correctPosition() {
 
 // insert the cursor rune array into r before the provided index
 func format(a []rune, c *Cursor) string {
+	return formatRanges(a, c, nil)
+}
+
+// formatRanges inserts the cursor like format, styling the runes of a in the given ranges.
+func formatRanges(a []rune, c *Cursor, ranges []Range) string {
 	i := c.Position
 	var b []rune
 
 	out := make([]rune, 0)
 	if i < len(a) {
 		b = c.Cursor(a[i : i+1])
-		out = append(out, a[:i]...)   // does not include i
-		out = append(out, b...)       // add the cursor
-		out = append(out, a[i+1:]...) // add the rest after i
+		// a cursor obscuring a wide rune is repeated to cover all its columns, keeping the rest of the
+		// input in place.
+		if w, cw := runeWidth(a[i]), DisplayWidth(string(b)); cw > 0 && cw < w {
+			b = []rune(strings.Repeat(string(b), (w+cw-1)/cw))
+		}
+		out = append(out, highlight(a[:i], 0, ranges)...)     // does not include i
+		out = append(out, b...)                               // add the cursor
+		out = append(out, highlight(a[i+1:], i+1, ranges)...) // add the rest after i
 	} else {
 		b = c.Cursor([]rune{})
-		out = append(out, a...)
+		out = append(out, highlight(a, 0, ranges)...)
 		out = append(out, b...)
 	}
 	return string(out)
 }
 
+// Range is a range of runes of the input, from Start included to End excluded, highlighted by the prompt's
+// HighlightFunc.
+type Range struct {
+	Start, End int
+
+	// Style styles the runes in the range, like a function returned by Styler. Defaults to red.
+	Style func(interface{}) string
+}
+
+// highlight styles the runes of a in the given ranges, a starting at the rune offset of the input. When ranges
+// overlap, the first one applies.
+func highlight(a []rune, offset int, ranges []Range) []rune {
+	if len(ranges) == 0 {
+		return a
+	}
+
+	at := func(i int) int {
+		for n, r := range ranges {
+			if i >= r.Start && i < r.End {
+				return n
+			}
+		}
+		return -1
+	}
+
+	out := make([]rune, 0, len(a))
+	for start := 0; start < len(a); {
+		n := at(offset + start)
+		end := start + 1
+		for end < len(a) && at(offset+end) == n {
+			end++
+		}
+
+		if n < 0 {
+			out = append(out, a[start:end]...)
+		} else {
+			style := ranges[n].Style
+			if style == nil {
+				style = Styler(FGRed)
+			}
+			out = append(out, []rune(style(string(a[start:end])))...)
+		}
+		start = end
+	}
+	return out
+}
+
 // Format renders the input with the Cursor appropriately positioned.
 func (c *Cursor) Format() string {
 	r := c.input
@@ -124,15 +199,27 @@ func (c *Cursor) Format() string {
 
 // FormatMask replaces all input runes with the mask rune.
 func (c *Cursor) FormatMask(mask rune) string {
-	if mask == ' ' {
+	return c.FormatMasks([]rune{mask})
+}
+
+// FormatMasks replaces all input runes with runes picked from masks. The rune used for a given position
+// never changes, so the output stays the same between refreshes.
+func (c *Cursor) FormatMasks(masks []rune) string {
+	if len(masks) == 1 && masks[0] == ' ' {
 		return format([]rune{}, c)
 	}
 
-	r := make([]rune, len(c.input))
+	return format(maskRunes(len(c.input), masks), c)
+}
+
+// maskRunes returns n runes picked from masks using a hash of their position.
+func maskRunes(n int, masks []rune) []rune {
+	r := make([]rune, n)
 	for i := range r {
-		r[i] = mask
+		h := uint32(i) * 2654435761
+		r[i] = masks[int(h>>16)%len(masks)]
 	}
-	return format(r, c)
+	return r
 }
 
 // Update inserts newinput into the input []rune in the appropriate place.
@@ -146,6 +233,11 @@ func (c *Cursor) Update(newinput string) {
 	c.Move(len(b))
 }
 
+// pristine reports whether the input is empty or only holds a default that the next key press erases.
+func (c *Cursor) pristine() bool {
+	return len(c.input) == 0 || c.erase
+}
+
 // Get returns a copy of the input
 func (c *Cursor) Get() string {
 	return string(c.input)
@@ -156,6 +248,11 @@ func (c *Cursor) GetMask(mask rune) string {
 	return strings.Repeat(string(mask), len(c.input))
 }
 
+// GetMasks returns a mask string with length equal to the input, using the same runes as FormatMasks.
+func (c *Cursor) GetMasks(masks []rune) string {
+	return string(maskRunes(len(c.input), masks))
+}
+
 // Replace replaces the previous input with whatever is specified, and moves the
 // cursor to the end position
 func (c *Cursor) Replace(input string) {
@@ -169,14 +266,23 @@ func (c *Cursor) Place(position int) {
 	c.correctPosition()
 }
 
-// Move moves the cursor over in relative terms, by shift indices.
+// Move moves the cursor over in relative terms, by shift indices. The cursor never stops on a zero width
+// rune, like a combining accent, since it is displayed as part of the rune before it.
 func (c *Cursor) Move(shift int) {
 	// delete the current cursor
 	c.Position = c.Position + shift
 	c.correctPosition()
+
+	for c.Position > 0 && c.Position < len(c.input) && runeWidth(c.input[c.Position]) == 0 {
+		if shift < 0 {
+			c.Position--
+		} else {
+			c.Position++
+		}
+	}
 }
 
-// Backspace removes the rune that precedes the cursor
+// Backspace removes the rune that precedes the cursor, along with the zero width runes displayed with it.
 //
 // It handles being at the beginning or end of the row, and moves the cursor to
 // the appropriate position.
@@ -187,13 +293,17 @@ func (c *Cursor) Backspace() {
 		// Shrug
 		return
 	}
+	start := i - 1
+	for start > 0 && runeWidth(a[start]) == 0 {
+		start--
+	}
 	if i == len(a) {
-		c.input = a[:i-1]
+		c.input = a[:start]
 	} else {
-		c.input = append(a[:i-1], a[i:]...)
+		c.input = append(a[:start], a[i:]...)
 	}
 	// now it's pointing to the i+1th element
-	c.Move(-1)
+	c.Move(start - i)
 }
 
 // Listen is a readline Listener that updates internal cursor state appropriately.
@@ -208,6 +318,9 @@ func (c *Cursor) Listen(line []rune, pos int, key rune) ([]rune, int, bool) {
 	case KeyEnter:
 		return []rune(c.Get()), c.Position, false
 	case KeyBackspace, KeyCtrlH:
+		if c.erase && c.keepOnBackspace {
+			break
+		}
 		if c.erase {
 			c.erase = false
 			c.Replace("")
@@ -219,7 +332,14 @@ func (c *Cursor) Listen(line []rune, pos int, key rune) ([]rune, int, bool) {
 		c.erase = false
 		c.Move(1)
 	case KeyBackward:
+		c.erase = false
 		c.Move(-1)
+	case KeyLineStart:
+		c.erase = false
+		c.Start()
+	case KeyLineEnd:
+		c.erase = false
+		c.End()
 	default:
 		if c.erase {
 			c.erase = false
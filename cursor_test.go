@@ -1,6 +1,9 @@
 package promptui
 
-import "testing"
+import (
+	"testing"
+	"unicode"
+)
 
 func TestDefinedCursors(t *testing.T) {
 	t.Run("pipeCursor", func(t *testing.T) {
@@ -101,3 +104,163 @@ func TestCursor(t *testing.T) {
 		}
 	})
 }
+
+func TestGlyphCursor(t *testing.T) {
+	cursor := NewCursor("abc", GlyphCursor('_', FGCyan), false)
+	cursor.Place(1)
+
+	if f := cursor.Format(); f != "a\033[36m_\033[0mc" {
+		t.Errorf("expected the glyph in place of b; found %q", f)
+	}
+
+	cursor = NewCursor("日本", GlyphCursor('_'), false)
+	cursor.Start()
+	if w := DisplayWidth(cursor.Format()); w != 4 {
+		t.Errorf("expected the glyph to cover the wide rune; found %d columns", w)
+	}
+}
+
+func TestCursorHighlight(t *testing.T) {
+	cursor := NewCursor("12345", pipeCursor, false)
+	bold := Styler(FGBold)
+
+	for _, c := range []struct {
+		position int
+		ranges   []Range
+		expected string
+	}{
+		{5, nil, "12345|"},
+		{5, []Range{{Start: 3, End: 5}}, "123\033[31m45\033[0m|"},
+		{4, []Range{{Start: 3, End: 5}}, "123\033[31m4\033[0m|5"},
+		{1, []Range{{Start: 0, End: 2, Style: bold}, {Start: 1, End: 3}}, "\033[1m1\033[0m|2\033[31m3\033[0m45"},
+	} {
+		cursor.Place(c.position)
+		if f := formatRanges(cursor.input, &cursor, c.ranges); f != c.expected {
+			t.Errorf("expected %q for %v at %d; found %q", c.expected, c.ranges, c.position, f)
+		}
+	}
+}
+
+func TestCursorMasks(t *testing.T) {
+	t.Run("single mask", func(t *testing.T) {
+		cursor := Cursor{input: []rune("secret"), Cursor: pipeCursor}
+		cursor.End()
+		if f := cursor.FormatMasks([]rune{'*'}); f != "******|" {
+			t.Errorf("expected ******|; found %s", f)
+		}
+	})
+
+	t.Run("multiple masks are stable", func(t *testing.T) {
+		masks := []rune("•◦")
+		cursor := Cursor{input: []rune("secret"), Cursor: pipeCursor}
+		cursor.End()
+		first := cursor.GetMasks(masks)
+
+		cursor.Update("!")
+		second := cursor.GetMasks(masks)
+		if second[:len(first)] != first {
+			t.Errorf("expected %q to start with %q", second, first)
+		}
+
+		if len([]rune(first)) != 6 {
+			t.Errorf("expected 6 mask runes; found %q", first)
+		}
+		for _, r := range first {
+			if r != '•' && r != '◦' {
+				t.Errorf("unexpected mask rune %q in %q", r, first)
+			}
+		}
+	})
+}
+
+func TestCursorEraseDefault(t *testing.T) {
+	// listen mimics readline, which only reports the typed rune in line since the prompt clears its buffer.
+	listen := func(c *Cursor, key rune) {
+		var line []rune
+		if unicode.IsPrint(key) {
+			line = []rune{key}
+		}
+		c.Listen(line, 0, key)
+	}
+
+	cases := []struct {
+		name      string
+		allowEdit bool
+		keep      bool
+		key       rune
+		expected  string
+	}{
+		{"first key backspace", false, false, KeyBackspace, "|"},
+		{"first key backspace keeping default", false, true, KeyBackspace, "|default"},
+		{"first key character", false, false, 'x', "x|"},
+		{"first key forward", false, false, KeyForward, "d|efault"},
+		{"first key backward", false, false, KeyBackward, "|default"},
+		{"first key line start", false, false, KeyLineStart, "|default"},
+		{"first key line end", false, false, KeyLineEnd, "default|"},
+		{"first key backspace with edit", true, false, KeyBackspace, "defaul|"},
+		{"first key character with edit", true, false, 'x', "defaultx|"},
+		{"first key forward with edit", true, false, KeyForward, "default|"},
+		{"first key backward with edit", true, false, KeyBackward, "defaul|t"},
+		{"first key line start with edit", true, false, KeyLineStart, "|default"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cursor := NewCursor("default", pipeCursor, !tc.allowEdit)
+			cursor.keepOnBackspace = tc.keep
+
+			listen(&cursor, tc.key)
+			if cursor.Format() != tc.expected {
+				t.Errorf("expected %s; found %s", tc.expected, cursor.Format())
+			}
+		})
+	}
+
+	t.Run("characters after an arrow key edit the default", func(t *testing.T) {
+		for _, key := range []rune{KeyForward, KeyBackward, KeyLineStart, KeyLineEnd} {
+			cursor := NewCursor("default", pipeCursor, true)
+			listen(&cursor, key)
+			listen(&cursor, 'x')
+
+			if cursor.Get() == "x" {
+				t.Errorf("expected default to be kept after %q; found %s", key, cursor.Format())
+			}
+		}
+	})
+}
+
+func TestCursorWide(t *testing.T) {
+	t.Run("default cursor covers wide runes", func(t *testing.T) {
+		for _, input := range []string{"日本語", "a😀b"} {
+			cursor := NewCursor(input, DefaultCursor, false)
+			for cursor.Start(); cursor.Position < len(cursor.input); cursor.Move(1) {
+				if w := DisplayWidth(cursor.Format()); w != DisplayWidth(input) {
+					t.Errorf("expected %q to use %d columns at position %d; found %d", cursor.Format(), DisplayWidth(input), cursor.Position, w)
+				}
+			}
+		}
+	})
+
+	t.Run("moves over combining marks", func(t *testing.T) {
+		cursor := NewCursor("e\u0301t", pipeCursor, false)
+		cursor.Start()
+		cursor.Move(1)
+		if f := cursor.Format(); f != "e\u0301|t" {
+			t.Errorf("expected the cursor after the accent; found %q", f)
+		}
+
+		cursor.Move(-1)
+		if f := cursor.Format(); f != "|e\u0301t" {
+			t.Errorf("expected the cursor before the accented rune; found %q", f)
+		}
+	})
+
+	t.Run("backspace removes combining marks with their rune", func(t *testing.T) {
+		cursor := NewCursor("e\u0301日", pipeCursor, false)
+		cursor.Move(-1)
+		cursor.Backspace()
+		if f := cursor.Format(); f != "|日" {
+			t.Errorf("expected |日; found %q", f)
+		}
+	})
+}
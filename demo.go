@@ -0,0 +1,56 @@
+package promptui
+
+import (
+	"io"
+	"os"
+	"time"
+	"unicode/utf8"
+)
+
+// DemoKey is a key of a DemoScript.
+type DemoKey struct {
+	// Rune is the key typed, a character or one of the keys of the package like KeyEnter or KeyBackspace.
+	Rune rune
+
+	// Delay is the time waited before typing the key.
+	Delay time.Duration
+}
+
+// DemoKeys returns a script typing text, waiting delay before each character.
+func DemoKeys(text string, delay time.Duration) []DemoKey {
+	var keys []DemoKey
+	for _, r := range text {
+		keys = append(keys, DemoKey{Rune: r, Delay: delay})
+	}
+	return keys
+}
+
+// demoReader types the keys of a DemoScript one at a time, then reads from the real input.
+type demoReader struct {
+	clock clock
+	keys  []DemoKey
+	r     io.Reader
+}
+
+func newDemoReader(c clock, keys []DemoKey, r io.Reader) *demoReader {
+	if r == nil {
+		r = os.Stdin
+	}
+	return &demoReader{clock: c, keys: keys, r: r}
+}
+
+// Read returns a single key of the script per call so readline handles each of them before the next delay.
+func (d *demoReader) Read(b []byte) (int, error) {
+	if len(d.keys) == 0 {
+		return d.r.Read(b)
+	}
+	if len(b) < utf8.UTFMax {
+		return 0, io.ErrShortBuffer
+	}
+
+	key := d.keys[0]
+	d.keys = d.keys[1:]
+
+	<-d.clock.After(key.Delay)
+	return utf8.EncodeRune(b, key.Rune), nil
+}
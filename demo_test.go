@@ -0,0 +1,47 @@
+package promptui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDemoScript(t *testing.T) {
+	t.Run("typed before the input", func(t *testing.T) {
+		r := &recordingRenderer{}
+		p := Prompt{
+			Label:      "Name",
+			DemoScript: DemoKeys("jo", time.Millisecond),
+			Renderer:   r,
+			Stdin:      strings.NewReader("hn\n"),
+			Stdout:     &bytes.Buffer{},
+		}
+
+		value, err := p.Run()
+		if err != nil || value != "john" {
+			t.Fatalf("expected the script to be followed by the input, got %q, %v", value, err)
+		}
+		if !strings.Contains(strings.Join(r.calls, "\n"), "jo") {
+			t.Errorf("expected the scripted keys to be drawn, got %q", r.calls)
+		}
+	})
+
+	t.Run("submitted by the script", func(t *testing.T) {
+		p := Prompt{
+			Label:      "Name",
+			DemoScript: append(DemoKeys("ab", 0), DemoKey{Rune: KeyBackspace}, DemoKey{Rune: KeyEnter, Delay: 10 * time.Millisecond}),
+			Stdin:      strings.NewReader("ignored\n"),
+			Stdout:     &bytes.Buffer{},
+		}
+
+		start := time.Now()
+		value, err := p.Run()
+		if err != nil || value != "a" {
+			t.Fatalf("expected the scripted value, got %q, %v", value, err)
+		}
+		if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+			t.Errorf("expected the delays to be waited, took %s", elapsed)
+		}
+	})
+}
@@ -0,0 +1,1031 @@
+package promptui
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/ergochat/readline"
+)
+
+// editState holds the state of a running prompt: the input being edited and what is displayed for it. Run feeds
+// it the keys read by readline, while Update lets an event loop owned by another library feed them instead.
+type editState struct {
+	p        *Prompt
+	renderer Renderer
+	cur      Cursor
+	masks    []rune
+	validFn  ValidateFunc
+
+	initialErr   error
+	touched      bool
+	vimInsert    bool
+	vimPending   rune
+	typed        bool
+	example      int
+	terminatedBy rune
+	accepted     bool
+	required     bool
+	armed        bool
+	aborted      bool
+	selected     bool
+
+	// when the default is submitted automatically, and whether it was.
+	autoSubmitAt  time.Time
+	autoSubmitted bool
+
+	// the last input given to OnChange, and the cancellation of the debounced next call.
+	lastChange   string
+	cancelChange func()
+
+	clock clock
+
+	// suspend runs fn with the terminal restored to its normal mode, nil when the prompt doesn't own it.
+	suspend func(fn func())
+
+	// width returns the width of the terminal, nil when the prompt isn't displayed on one.
+	width func() int
+
+	// the words matching the input when Tab was first pressed, and the one currently completed.
+	completions []string
+	completion  int
+	// the index of the first completion listed with MaxVisibleSuggestions.
+	suggestionsTop int
+
+	// the values recalled with VerticalHistoryNav since the first Up, the one in the input, -1 for the input typed
+	// before, and that input.
+	history  []string
+	recalled int
+	draft    Cursor
+
+	// the fixes suggested by SuggestMany for the last validated input, the ones listed after it was rejected on
+	// Enter and the one picked among them.
+	suggested []string
+	fixes     []string
+	fix       int
+
+	// the examples rotate from another goroutine, so it takes turns with the key handlers using mu.
+	mu sync.Mutex
+}
+
+// newEditState starts editing the input, drawing with renderer. The templates must have been prepared.
+func (p *Prompt) newEditState(renderer Renderer) *editState {
+	var s *editState
+
+	validFn := func(x string) error {
+		return nil
+	}
+	if p.Validate != nil {
+		validFn = p.Validate
+	}
+	if p.ValidateData != nil {
+		validFn = func(x string) error {
+			data, err := p.ValidateData(x)
+			p.Templates.data = data
+			return err
+		}
+	}
+	if p.SuggestMany != nil {
+		validFn = func(x string) error {
+			suggestions, err := p.SuggestMany(x)
+			s.suggested = nil
+			if err != nil {
+				s.suggested = suggestions
+			}
+			return err
+		}
+	}
+
+	if p.listDelim != 0 {
+		validFn = p.validateList(validFn)
+	}
+
+	if len(p.Requirements) != 0 && p.EnforceRequirements {
+		validate := validFn
+		validFn = func(x string) error {
+			if err := p.unmetRequirement(x); err != nil {
+				return err
+			}
+			return validate(x)
+		}
+	}
+
+	// the input is painted after every key, including the ones leaving it unchanged like cursor moves, and
+	// validators may be slow or call a remote service. The last result is reused until the input changes.
+	var (
+		validated     bool
+		lastValidated string
+		lastErr       error
+	)
+	clock := p.timeSource()
+	cachedFn := func(x string) error {
+		switch {
+		case validated && x == lastValidated:
+			return lastErr
+		// the entries of a list are validated on their own, which the delta of the whole input can't tell.
+		case validated && p.ValidateIncremental != nil && p.listDelim == 0 && strings.HasPrefix(x, lastValidated):
+			// the delta is still validated to keep up with the input, but an unmet requirement comes first.
+			err := p.ValidateIncremental.ValidateDelta(lastValidated, x)
+			if unmet := p.unmetRequirement(x); unmet != nil {
+				err = unmet
+			}
+			lastValidated, lastErr = x, err
+		default:
+			validated, lastValidated, lastErr = true, x, validFn(x)
+		}
+
+		if p.ValidationTrace != nil {
+			*p.ValidationTrace = append(*p.ValidationTrace, ValidationRecord{Input: x, Err: lastErr, Time: clock.Now()})
+		}
+		return lastErr
+	}
+
+	input := p.Default
+	if p.IsConfirm || (p.MaskedDefault == MaskedDefaultOmit && len(p.masks()) != 0) {
+		input = ""
+	}
+	eraseDefault := input != "" && !p.AllowEdit
+	cur := NewCursor(input, p.Pointer, eraseDefault)
+	cur.keepOnBackspace = p.KeepDefaultOnBackspace
+
+	s = &editState{
+		p:          p,
+		renderer:   renderer,
+		cur:        cur,
+		masks:      p.masks(),
+		validFn:    cachedFn,
+		initialErr: p.InitialError,
+		touched:    !p.ValidateAfterTouch,
+		vimInsert:  true,
+		lastChange: cur.Get(),
+		clock:      clock,
+	}
+
+	if p.restored != nil {
+		s.restore(*p.restored)
+		s.lastChange = s.cur.Get()
+		p.restored = nil
+	}
+
+	return s
+}
+
+// restore replaces the input with state, which is edited like typed text rather than erased like a default.
+func (s *editState) restore(state PromptState) {
+	s.cur.erase = false
+	s.cur.input = []rune(state.Input)
+	s.cur.Place(state.Position)
+}
+
+func (s *editState) paint() {
+	p := s.p
+
+	// every change of the input is followed by a paint, making it the place to report them.
+	s.changed()
+	label := p.Templates.unvalidated
+	var badge *template.Template
+
+	if !p.LazyValidation && (p.IsBusy == nil || !p.IsBusy()) {
+		err := s.validFn(s.value())
+
+		switch {
+		case err != nil && !s.touched:
+		case err != nil:
+			label, badge = p.Templates.invalid, p.Templates.invalidBadge
+		case p.IsConfirm:
+			label = p.Templates.prompt
+		default:
+			label, badge = p.Templates.valid, p.Templates.validBadge
+		}
+	}
+
+	if p.ValidationBadge {
+		label = p.Templates.prompt
+	}
+
+	if p.ReadOnly {
+		label, badge = p.Templates.valid, nil
+	}
+
+	prompt := p.renderLabel(label)
+	if p.Description != "" {
+		prompt = append(prompt, '\n')
+		prompt = append(prompt, render(p.Templates.description, p.Description)...)
+		prompt = append(prompt, '\n')
+	} else if p.LabelPosition == LabelAbove {
+		prompt = append(prompt, '\n')
+	}
+
+	if len(p.Requirements) != 0 {
+		// each line of the checklist starts with its line break.
+		prompt = bytes.TrimSuffix(prompt, []byte("\n"))
+		prompt = append(prompt, render(p.Templates.checklist, checkRequirements(p.Requirements, s.value()))...)
+		prompt = append(prompt, '\n')
+	}
+
+	input := s.cur.input
+	if p.EchoTransforms {
+		input = []rune(s.value())
+	}
+	echo := format(input, &s.cur)
+	switch {
+	case s.selected && s.cur.erase:
+		echo = formatRanges(input, &s.cur, []Range{{Start: 0, End: len(input), Style: Styler(BGBlue)}})
+	case p.listDelim != 0:
+		echo = s.chips(input)
+	case p.HighlightFunc != nil:
+		echo = formatRanges(input, &s.cur, p.HighlightFunc(string(input)))
+	}
+	switch {
+	case p.ReadOnly && len(s.masks) != 0:
+		echo = s.cur.GetMasks(s.masks)
+	case p.ReadOnly:
+		echo = s.cur.Get()
+	case len(s.masks) != 0 && p.IsConfirm:
+		echo = format(nil, &s.cur)
+	case len(s.masks) != 0 && p.MaskedDefault == MaskedDefaultReveal && !s.typed:
+		// the default is displayed in clear text until the first key.
+	case len(s.masks) != 0:
+		echo = s.cur.FormatMasks(s.masks)
+	}
+
+	prompt = append(prompt, []byte(echo)...)
+
+	if ghost := s.ghost(); ghost != "" {
+		prompt = append(prompt, Styler(FGFaint)(ghost)...)
+	}
+
+	if s.required {
+		prompt = append(prompt, Styler(FGFaint)(" (required)")...)
+	}
+
+	if s.armed {
+		prompt = append(prompt, Styler(FGFaint)(" (press Enter again to confirm)")...)
+	}
+
+	if len(p.Examples) != 0 && s.cur.Get() == "" {
+		prompt = append(prompt, Styler(FGFaint)(p.Examples[s.example])...)
+	}
+
+	if !s.autoSubmitAt.IsZero() && !s.typed {
+		left := (s.autoSubmitAt.Sub(s.clock.Now()) + time.Second - 1).Truncate(time.Second)
+		if p.TimeoutWarning > 0 && left <= p.TimeoutWarning {
+			prompt = append(prompt, render(p.Templates.warning, int(left/time.Second))...)
+		} else {
+			prompt = append(prompt, Styler(FGFaint)(fmt.Sprintf(" (auto in %s)", left))...)
+		}
+	}
+
+	if p.ValidationBadge && badge != nil {
+		prompt = append(prompt, render(badge, p.Label)...)
+	}
+
+	if len(p.Options) != 0 && s.cur.pristine() {
+		prompt = append(prompt, optionsHint(p.Options)...)
+	}
+
+	if p.IsVimMode {
+		mode := "NORMAL"
+		if s.vimInsert {
+			mode = "INSERT"
+		}
+		prompt = append(prompt, render(p.Templates.vimMode, mode)...)
+	}
+
+	if p.MaxVisibleSuggestions > 0 && len(s.completions) != 0 {
+		prompt = append(prompt, s.suggestions()...)
+	}
+
+	if rules, ok := p.Templates.data["rules"].([]RuleResult); ok && p.ValidationChecklist {
+		prompt = append(prompt, render(p.Templates.checklist, rules)...)
+	}
+
+	if warnings, ok := p.Templates.data["warnings"].([]RuleResult); ok && p.ValidationWarnings {
+		for _, w := range warnings {
+			prompt = append(prompt, render(p.Templates.ruleWarning, w)...)
+		}
+	}
+
+	s.renderer.DrawPrompt(s.framed(string(prompt)))
+}
+
+// framed returns the text drawn in place of the prompt inside the Frame, if any.
+func (s *editState) framed(text string) string {
+	if s.p.Frame == nil {
+		return text
+	}
+	return s.p.Frame.draw(text)
+}
+
+// listen is the readline Listener of the prompt, called after each key once readline has handled it.
+func (s *editState) listen(input []rune, pos int, key rune) ([]rune, int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.p
+
+	if key != 0 {
+		s.typed = true
+		s.required = false
+	}
+
+	if i := int(key - '1'); i >= 0 && i < len(p.Options) && i < 9 && s.cur.pristine() {
+		s.cur.erase = false
+		s.cur.Replace(p.Options[i])
+		s.paint()
+		return nil, 0, true
+	}
+
+	edit := key
+	if !p.AllowControlChars {
+		input, edit = sanitize(input, key)
+	}
+	_, _, keepOn := s.cur.Listen(input, pos, edit)
+
+	if s.initialErr != nil {
+		if key == 0 {
+			s.renderer.DrawError(s.framed(s.renderValidation(s.initialErr)))
+			return nil, 0, keepOn
+		}
+		s.initialErr = nil
+	}
+
+	s.paint()
+	return nil, 0, keepOn
+}
+
+// sanitize drops the control characters of input, except whitespace. A control key not editing the input is
+// replaced by 0 so the cursor doesn't insert it.
+func sanitize(input []rune, key rune) ([]rune, rune) {
+	var clean []rune
+	for _, r := range input {
+		if !unicode.IsControl(r) || unicode.IsSpace(r) {
+			clean = append(clean, r)
+		}
+	}
+
+	switch key {
+	case KeyEnter, KeyBackspace, KeyCtrlH, KeyForward, KeyBackward, KeyLineStart, KeyLineEnd:
+	default:
+		if unicode.IsControl(key) && !unicode.IsSpace(key) {
+			key = 0
+		}
+	}
+	return clean, key
+}
+
+// submit validates the input before it is submitted, displaying the validation error if there is one.
+func (s *editState) submit() bool {
+	s.touched = true
+	err := s.validFn(s.value())
+	s.fixes, s.fix = nil, 0
+	if err != nil {
+		msg := s.renderValidation(err)
+		if len(s.suggested) != 0 {
+			s.fixes = s.suggested
+			msg += pickList(s.fixes, 0)
+		}
+		s.renderer.DrawError(s.framed(msg))
+		return false
+	}
+	return true
+}
+
+// renderValidation renders the validation error err. The lines following the first one in a multi-line error
+// are indented to start in the same column as it.
+func (s *editState) renderValidation(err error) string {
+	var data interface{} = err
+	msg := err.Error()
+	if s.p.ErrorFormatter != nil {
+		msg = s.p.ErrorFormatter(err)
+		data = msg
+	}
+
+	out := string(render(s.p.Templates.validation, data))
+
+	i := strings.Index(out, msg)
+	if i < 0 || !strings.Contains(msg, "\n") {
+		return out
+	}
+
+	indent := strings.Repeat(" ", DisplayWidth(out[:i]))
+	return out[:i] + strings.ReplaceAll(msg, "\n", "\n"+indent) + out[i+len(msg):]
+}
+
+// abortKey returns the AbortKey of the prompt, zero when it has none.
+func (p *Prompt) abortKey() rune {
+	switch {
+	case p.AbortKey == NoAbortKey:
+		return 0
+	case p.AbortKey != 0:
+		return p.AbortKey
+	case p.IsVimMode:
+		return 0
+	}
+	return readline.CharEsc
+}
+
+// filter is the readline FuncFilterInputRune of the prompt, called with each key before readline handles it.
+func (s *editState) filter(r rune) (rune, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.p
+
+	// the abort key ends the prompt like an interrupt, which Run then reports as an abort.
+	if key := p.abortKey(); key != 0 && (r == key || (key == readline.CharEsc && r == loneEscape)) {
+		s.aborted = true
+		return readline.CharInterrupt, true
+	}
+
+	// with DoubleConfirm, only the key following the first Enter can submit the value.
+	armed := s.armed
+	s.armed = false
+
+	// a read only prompt can't be edited, so every key but an interrupt submits it as is.
+	if p.ReadOnly && r != readline.CharInterrupt {
+		s.terminatedBy = r
+		return readline.CharEnter, true
+	}
+
+	if p.AcceptKey != nil && p.AcceptKey(r) {
+		s.cur.Replace(string(r))
+		s.terminatedBy, s.accepted = r, true
+		return readline.CharEnter, true
+	}
+
+	if p.AllowEditorLaunch && r == KeyEditor {
+		s.edit()
+		return r, false
+	}
+
+	if p.AllowSelectAll && r == KeySelectAll {
+		s.selected = true
+		s.cur.erase = true
+		s.cur.End()
+		s.paint()
+		return r, false
+	}
+
+	// the selection is the erasable input of the cursor, which only printable keys replace.
+	if s.selected {
+		s.selected = false
+		switch r {
+		case KeyBackspace, KeyCtrlH:
+			s.cur.erase = false
+			s.cur.Replace("")
+			s.typed = true
+			s.paint()
+			return r, false
+		case KeyBackward, KeyPrev, KeyLineStart:
+			s.cur.erase = false
+			s.cur.Start()
+			s.paint()
+			return r, false
+		case KeyForward, KeyNext, KeyLineEnd:
+			s.cur.erase = false
+			s.cur.End()
+			s.paint()
+			return r, false
+		default:
+			if !unicode.IsPrint(r) {
+				s.cur.erase = false
+			}
+		}
+	}
+
+	if p.IsVimMode {
+		var handled bool
+		if r, handled = s.vim(r); handled {
+			return r, false
+		}
+	} else if r == loneEscape {
+		// a Session may keep reading lone ESC keys for a previous prompt.
+		return r, false
+	}
+
+	if p.RawMode && isRawKey(r) {
+		if p.OnRawKey != nil {
+			s.cur.Replace(p.OnRawKey(r, s.cur.Get()))
+		}
+		s.paint()
+		return r, false
+	}
+
+	if p.listDelim != 0 && (r == KeyBackspace || r == KeyCtrlH) && s.removeChip() {
+		s.typed = true
+		s.paint()
+		return r, false
+	}
+
+	if len(s.fixes) != 0 {
+		switch r {
+		case KeyNext, KeyPrev:
+			n := len(s.fixes)
+			if r == KeyNext {
+				s.fix = (s.fix + 1) % n
+			} else {
+				s.fix = (s.fix + n - 1) % n
+			}
+			s.renderer.DrawError(s.framed(s.renderValidation(s.validFn(s.value())) + pickList(s.fixes, s.fix)))
+			return r, false
+		case readline.CharEnter, readline.CharCtrlJ:
+			s.cur.erase = false
+			s.cur.Replace(s.fixes[s.fix])
+			s.typed = true
+			if s.submit() {
+				s.paint()
+			}
+			return r, false
+		}
+		s.fixes = nil
+	}
+
+	if ghost := s.ghost(); ghost != "" && (r == KeyTab || r == KeyForward) {
+		s.cur.Replace(s.current() + ghost)
+		s.cur.erase = false
+		s.typed = true
+		s.paint()
+		return r, false
+	}
+
+	if r == KeyTab {
+		switch {
+		case p.TabBehavior == TabIgnore:
+			return r, false
+		case p.TabBehavior > 0:
+			if s.cur.erase {
+				s.cur.erase = false
+				s.cur.Replace("")
+			}
+			s.typed = true
+			s.cur.Update(strings.Repeat(" ", int(p.TabBehavior)))
+			s.paint()
+			return r, false
+		case p.TabBehavior == TabComplete && len(s.words()) != 0:
+			s.complete(1)
+			return r, false
+		}
+	}
+
+	if len(s.completions) != 0 && p.MaxVisibleSuggestions > 0 {
+		switch r {
+		case KeyNext:
+			s.complete(1)
+			return r, false
+		case KeyPrev:
+			s.complete(-1)
+			return r, false
+		}
+	}
+	s.completions = nil
+
+	switch p.VerticalKeyBehavior {
+	case VerticalStartEnd:
+		switch r {
+		case KeyPrev:
+			r = KeyLineStart
+		case KeyNext:
+			r = KeyLineEnd
+		}
+	case VerticalHistoryNav:
+		if r == KeyPrev || r == KeyNext {
+			if len(s.masks) == 0 {
+				s.recall(r == KeyPrev)
+			}
+			return r, false
+		}
+		s.history = nil
+	}
+
+	if _, ok := p.TriggerRunes[r]; ok {
+		if p.TriggerSkipsValidation || s.submit() {
+			s.terminatedBy = r
+			return readline.CharEnter, true
+		}
+		return r, false
+	}
+
+	if p.FilterInput != nil && unicode.IsPrint(r) {
+		current := s.cur.Get()
+		if s.cur.erase {
+			current = ""
+		}
+		if !p.FilterInput(current, r) {
+			return r, false
+		}
+	}
+
+	switch r {
+	case readline.CharEnter, readline.CharCtrlJ:
+		omitted := p.MaskedDefault == MaskedDefaultOmit && len(s.masks) != 0 && s.cur.Get() == ""
+		if omitted {
+			s.cur.Replace(p.Default)
+		}
+		if p.SoftRequired && s.cur.Get() == "" {
+			s.required = true
+			if bell, ok := s.renderer.(bellRenderer); ok {
+				bell.Bell()
+			}
+			s.paint()
+			return r, false
+		}
+		if !s.submit() {
+			if omitted {
+				s.cur.Replace("")
+			}
+			return r, false
+		}
+		if p.DoubleConfirm && !armed {
+			if omitted {
+				s.cur.Replace("")
+			}
+			s.armed = true
+			s.paint()
+			return r, false
+		}
+		s.terminatedBy = r
+		return r, true
+	}
+	return r, true
+}
+
+// value returns the input with the prompt's Transforms applied.
+func (s *editState) value() string {
+	return s.p.transform(s.cur.Get())
+}
+
+// changed calls OnChange when the input differs from the last value it was given, after ChangeDebounce.
+func (s *editState) changed() {
+	p := s.p
+	value := s.cur.Get()
+	if p.OnChange == nil || value == s.lastChange {
+		return
+	}
+	s.lastChange = value
+
+	if p.ChangeDebounce <= 0 {
+		p.OnChange(value)
+		return
+	}
+
+	if s.cancelChange != nil {
+		s.cancelChange()
+	}
+	s.cancelChange = afterFunc(s.clock, p.ChangeDebounce, func() {
+		p.OnChange(value)
+	})
+}
+
+// words returns the vocabulary completing the input: the prompt's Words, followed by the values of the previous
+// prompts when UseSessionSuggestions is set.
+func (s *editState) words() []string {
+	if !s.p.UseSessionSuggestions {
+		return s.p.Words
+	}
+
+	words := append([]string(nil), s.p.Words...)
+	for _, v := range SessionSuggestions() {
+		known := false
+		for _, w := range s.p.Words {
+			known = known || w == v
+		}
+		if !known {
+			words = append(words, v)
+		}
+	}
+	return words
+}
+
+// edit replaces the input with the text edited in the editor, then validates it.
+func (s *editState) edit() {
+	var (
+		text string
+		err  error
+	)
+	run := func() {
+		text, err = editText(s.cur.Get())
+	}
+	if s.suspend != nil {
+		s.suspend(run)
+	} else {
+		run()
+	}
+
+	if err != nil {
+		s.renderer.DrawError(s.framed(s.renderValidation(err)))
+		return
+	}
+
+	s.cur.erase = false
+	s.cur.Replace(text)
+	s.typed, s.touched = true, true
+
+	if err := s.validFn(s.value()); err != nil {
+		s.renderer.DrawError(s.framed(s.renderValidation(err)))
+		return
+	}
+	s.paint()
+}
+
+// complete replaces the input with the word step words after the current completion, among the words starting
+// with the input typed before the first Tab, best matches first.
+func (s *editState) complete(step int) {
+	if s.completions == nil {
+		prefix := s.cur.Get()
+		if s.cur.erase {
+			prefix = ""
+		}
+
+		type match struct {
+			word  string
+			score int
+		}
+		var matches []match
+		for _, w := range s.words() {
+			if !strings.HasPrefix(w, prefix) {
+				continue
+			}
+			score, _ := FuzzyMatch(prefix, w)
+			matches = append(matches, match{w, score})
+		}
+		// ties keep the order of the words.
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+		s.completions = make([]string, len(matches))
+		for i, m := range matches {
+			s.completions[i] = m.word
+		}
+		s.completion, s.suggestionsTop = -1, 0
+	}
+
+	if len(s.completions) == 0 {
+		return
+	}
+
+	n := len(s.completions)
+	s.completion = ((s.completion+step)%n + n) % n
+	if visible := s.p.MaxVisibleSuggestions; visible > 0 {
+		switch {
+		case s.completion < s.suggestionsTop:
+			s.suggestionsTop = s.completion
+		case s.completion >= s.suggestionsTop+visible:
+			s.suggestionsTop = s.completion - visible + 1
+		}
+	}
+	s.cur.erase = false
+	s.cur.Replace(s.completions[s.completion])
+	s.paint()
+}
+
+// suggestions renders the lines listing the completions visible with MaxVisibleSuggestions, the current one
+// marked.
+func (s *editState) suggestions() string {
+	var sb strings.Builder
+
+	end := s.suggestionsTop + s.p.MaxVisibleSuggestions
+	if end > len(s.completions) {
+		end = len(s.completions)
+	}
+
+	sb.WriteString(pickList(s.completions[s.suggestionsTop:end], s.completion-s.suggestionsTop))
+
+	if hidden := len(s.completions) - (end - s.suggestionsTop); hidden > 0 {
+		sb.WriteString("\n  " + Styler(FGFaint)(fmt.Sprintf("(+%d more)", hidden)))
+	}
+	return sb.String()
+}
+
+// recall replaces the input with the previous value accepted by the program when older is set, or with the next
+// one, back to the input typed before the first Up. It does nothing past either end.
+func (s *editState) recall(older bool) {
+	if s.history == nil {
+		s.history, s.recalled, s.draft = SessionSuggestions(), -1, s.cur
+	}
+
+	i := s.recalled - 1
+	if older {
+		i = s.recalled + 1
+	}
+	if i < -1 || i >= len(s.history) {
+		return
+	}
+
+	s.recalled = i
+	if i == -1 {
+		s.cur = s.draft
+	} else {
+		s.cur.erase = false
+		s.cur.Replace(s.history[i])
+	}
+	s.typed = true
+	s.paint()
+}
+
+// current returns the input being edited, empty while a default is about to be erased.
+func (s *editState) current() string {
+	if s.cur.erase {
+		return ""
+	}
+	return s.cur.Get()
+}
+
+// ghost returns the rest of the value suggested by DynamicDefault for the input, displayed after the cursor
+// while it is at the end of the input.
+func (s *editState) ghost() string {
+	p := s.p
+	if p.DynamicDefault == nil || len(s.masks) != 0 || p.ReadOnly || s.cur.Position != len(s.cur.input) {
+		return ""
+	}
+
+	current := s.current()
+	if suggested := p.DynamicDefault(current); strings.HasPrefix(suggested, current) {
+		return suggested[len(current):]
+	}
+	return ""
+}
+
+// pickList renders items on the lines following the input, the current one marked.
+func pickList(items []string, current int) string {
+	var sb strings.Builder
+	for i, item := range items {
+		if i == current {
+			sb.WriteString("\n" + iconSelect() + " " + item)
+		} else {
+			sb.WriteString("\n  " + Styler(FGFaint)(item))
+		}
+	}
+	return sb.String()
+}
+
+// rotateExamples shows the next of the prompt's Examples on every tick until a key is pressed.
+func (s *editState) rotateExamples(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-s.clock.After(exampleInterval):
+		}
+
+		s.mu.Lock()
+		if !s.typed {
+			s.example = (s.example + 1) % len(s.p.Examples)
+			s.paint()
+		}
+		s.mu.Unlock()
+	}
+}
+
+// autoSubmit submits the default with submit once the AutoSubmitDefault delay has elapsed without a key press,
+// counting down every second until then.
+func (s *editState) autoSubmit(submit func(), stop <-chan struct{}) {
+	timer := s.clock.After(s.autoSubmitAt.Sub(s.clock.Now()))
+	tick := s.clock.After(time.Second)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-tick:
+			tick = s.clock.After(time.Second)
+			s.mu.Lock()
+			if !s.typed {
+				s.paint()
+			}
+			s.mu.Unlock()
+		case <-timer:
+			s.mu.Lock()
+			submitted := !s.typed
+			s.autoSubmitted = submitted
+			s.mu.Unlock()
+
+			if submitted {
+				submit()
+			}
+			return
+		}
+	}
+}
+
+// stop ends the interactive part of the editing, once no more keys are expected.
+func (s *editState) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.typed = true
+	if s.cancelChange != nil {
+		s.cancelChange()
+	}
+}
+
+// finish returns the line displayed once the value has been submitted and the error of the prompt, recording
+// how it ended in the prompt's RunResult.
+func (s *editState) finish() (string, error) {
+	p := s.p
+	value := s.value()
+
+	var err error
+	width := 0
+	if s.width != nil {
+		width = s.width()
+	}
+	prompt := p.successLine(value, width)
+
+	if p.IsConfirm && !p.confirmAccepted(value) {
+		prompt = p.renderLabel(p.Templates.invalid)
+		err = ErrAbort
+	}
+
+	if !p.NoTrailingNewline {
+		prompt = append(prompt, '\n')
+	}
+
+	if err == nil && value != "" && !p.IsConfirm && len(s.masks) == 0 {
+		rememberValue(value)
+	}
+
+	_, triggered := p.TriggerRunes[s.terminatedBy]
+	p.result = RunResult{Value: value, TerminatedBy: s.terminatedBy, Triggered: triggered || s.accepted}
+
+	return string(prompt), err
+}
+
+// Update feeds a key to the prompt without reading its input, for programs that own the event loop, like TUI
+// frameworks. It returns the rendered prompt line, ready to be displayed by the caller. Once the prompt has
+// ended, done is set and value and err hold what Run would have returned.
+//
+// The first call starts the prompt, and a key of 0 only renders it. Calling Update after the prompt has ended
+// starts it again. The Examples don't rotate, as Update only renders the prompt when it is called.
+func (p *Prompt) Update(key rune) (rendered string, done bool, value string, err error) {
+	if p.editing == nil {
+		err = p.prepareTemplates()
+		if err != nil {
+			return "", true, "", err
+		}
+
+		if p.DefaultFunc != nil {
+			p.Default = p.DefaultFunc()
+		}
+
+		p.editing = p.newEditState(&frameRenderer{})
+		p.editing.listen(nil, 0, 0)
+	}
+
+	s := p.editing
+	frame := s.renderer.(*frameRenderer)
+
+	switch key {
+	case 0:
+		return frame.frame, false, "", nil
+	case readline.CharInterrupt:
+		p.editing = nil
+		return frame.frame, true, "", ErrInterrupt
+	case readline.CharEOT:
+		if s.cur.Get() == "" {
+			p.editing = nil
+			value, err = p.endOfInput()
+			return frame.frame, true, value, err
+		}
+	}
+
+	key, ok := s.filter(key)
+	if !ok {
+		return frame.frame, false, "", nil
+	}
+
+	if s.aborted {
+		p.editing = nil
+		return frame.frame, true, "", ErrAbort
+	}
+
+	if key == readline.CharEnter || key == readline.CharCtrlJ {
+		p.editing = nil
+		rendered, err = s.finish()
+		return rendered, true, s.value(), err
+	}
+
+	// like readline, only report printable keys and tabs as input: the cursor handles the others itself.
+	var line []rune
+	if unicode.IsPrint(key) || key == readline.CharTab {
+		line = []rune{key}
+	}
+	s.listen(line, 0, key)
+
+	return frame.frame, false, "", nil
+}
+
+// frameRenderer keeps the last frame drawn by a prompt driven with Update.
+type frameRenderer struct {
+	frame string
+}
+
+func (r *frameRenderer) DrawPrompt(prompt string) { r.frame = prompt }
+func (r *frameRenderer) DrawError(message string) { r.frame = message }
+func (r *frameRenderer) DrawSuccess(line string)  { r.frame = line }
+func (r *frameRenderer) HideCursor()              {}
+func (r *frameRenderer) ShowCursor()              {}
@@ -0,0 +1,701 @@
+package promptui
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPromptUpdate(t *testing.T) {
+	t.Run("renders and submits the input", func(t *testing.T) {
+		p := Prompt{Label: "Name", Pointer: PipeCursor}
+
+		rendered, done, _, _ := p.Update(0)
+		if done || !strings.HasSuffix(rendered, "|") {
+			t.Fatalf("expected the empty prompt, got %q", rendered)
+		}
+
+		for _, key := range "ab" {
+			rendered, done, _, _ = p.Update(key)
+		}
+		if done || !strings.HasSuffix(rendered, "ab|") {
+			t.Fatalf("expected the input to be rendered, got %q", rendered)
+		}
+
+		rendered, _, _, _ = p.Update(KeyBackward)
+		if !strings.HasSuffix(rendered, "a|b") {
+			t.Errorf("expected the cursor to move, got %q", rendered)
+		}
+
+		rendered, done, value, err := p.Update(KeyEnter)
+		if !done || err != nil || value != "ab" {
+			t.Fatalf("expected ab to be submitted, got %q, %v, %v", value, done, err)
+		}
+		if !strings.HasSuffix(rendered, "ab\n") {
+			t.Errorf("expected the success line, got %q", rendered)
+		}
+
+		rendered, done, _, _ = p.Update(0)
+		if done || !strings.HasSuffix(rendered, "|") {
+			t.Errorf("expected the prompt to start again, got %q", rendered)
+		}
+	})
+
+	t.Run("displays validation errors", func(t *testing.T) {
+		p := Prompt{
+			Label:     "Name",
+			Validate:  func(s string) error { return ErrAbort },
+			Templates: &PromptTemplates{ValidationError: "error"},
+		}
+
+		rendered, done, _, _ := p.Update(KeyEnter)
+		if done || rendered != "error" {
+			t.Errorf("expected the validation error, got %q, %v", rendered, done)
+		}
+	})
+
+	t.Run("indents multi-line validation errors", func(t *testing.T) {
+		p := Prompt{
+			Label:     "Password",
+			Validate:  func(s string) error { return errors.New("too short\nno digit") },
+			Templates: &PromptTemplates{ValidationError: ">> {{ . }}"},
+		}
+
+		rendered, _, _, _ := p.Update(KeyEnter)
+		if rendered != ">> too short\n   no digit" {
+			t.Errorf("expected the second line to be indented, got %q", rendered)
+		}
+	})
+
+	t.Run("formats validation errors", func(t *testing.T) {
+		p := Prompt{
+			Label:          "Name",
+			Validate:       func(s string) error { return errors.New("required") },
+			ErrorFormatter: func(err error) string { return "Error: " + strings.ToUpper(err.Error()[:1]) + err.Error()[1:] + "." },
+			Templates:      &PromptTemplates{ValidationError: ">> {{ . }}"},
+		}
+
+		rendered, _, _, _ := p.Update(KeyEnter)
+		if rendered != ">> Error: Required." {
+			t.Errorf("expected the formatted error, got %q", rendered)
+		}
+	})
+
+	t.Run("interrupts", func(t *testing.T) {
+		p := Prompt{Label: "Name"}
+
+		_, done, _, err := p.Update(3)
+		if !done || err != ErrInterrupt {
+			t.Errorf("expected ErrInterrupt, got %v, %v", done, err)
+		}
+	})
+}
+
+func TestPromptWords(t *testing.T) {
+	p := Prompt{Label: "Command", Words: []string{"checkout", "cherry-pick", "commit"}}
+
+	for _, key := range "ch" {
+		p.Update(key)
+	}
+
+	var value string
+	for _, expected := range []string{"checkout", "cherry-pick", "checkout"} {
+		p.Update(KeyTab)
+		value = p.editing.cur.Get()
+		if value != expected {
+			t.Errorf("expected %q after Tab, got %q", expected, value)
+		}
+	}
+
+	p.Update('s')
+	p.Update(KeyTab)
+	if value = p.editing.cur.Get(); value != "checkouts" {
+		t.Errorf("expected no completion for an unknown prefix, got %q", value)
+	}
+}
+
+func TestPromptWordsRanked(t *testing.T) {
+	p := Prompt{Label: "Command", Words: []string{"recompile", "compiler", "run", "compile", "cpl"}}
+
+	for _, key := range "comp" {
+		p.Update(key)
+	}
+
+	// recompile and cpl match "comp" fuzzily, but don't start with it.
+	for _, expected := range []string{"compile", "compiler", "compile"} {
+		p.Update(KeyTab)
+		if value := p.editing.cur.Get(); value != expected {
+			t.Errorf("expected %q after Tab, got %q", expected, value)
+		}
+	}
+}
+
+func TestPromptVerticalKeyBehavior(t *testing.T) {
+	p := Prompt{Label: "Name", Pointer: PipeCursor}
+	for _, key := range []rune{'a', 'b', KeyPrev, 'c'} {
+		p.Update(key)
+	}
+	if value := p.editing.cur.Get(); value != "abc" {
+		t.Errorf("expected Up to be ignored, got %q", value)
+	}
+
+	p = Prompt{Label: "Name", VerticalKeyBehavior: VerticalStartEnd, Pointer: PipeCursor}
+	for _, key := range []rune{'a', 'b', KeyPrev, 'c', KeyNext, 'd'} {
+		p.Update(key)
+	}
+	if value := p.editing.cur.Get(); value != "cabd" {
+		t.Errorf("expected Up and Down to move to the start and the end, got %q", value)
+	}
+
+	ClearSessionSuggestions()
+	defer ClearSessionSuggestions()
+	rememberValue("db.local")
+	rememberValue("api.local")
+
+	p = Prompt{Label: "Host", VerticalKeyBehavior: VerticalHistoryNav}
+	p.Update('x')
+	for _, c := range []struct {
+		key      rune
+		expected string
+	}{
+		{KeyPrev, "api.local"},
+		{KeyPrev, "db.local"},
+		{KeyPrev, "db.local"},
+		{KeyNext, "api.local"},
+		{KeyNext, "x"},
+		{KeyNext, "x"},
+	} {
+		p.Update(c.key)
+		if value := p.editing.cur.Get(); value != c.expected {
+			t.Errorf("expected %q once recalling the history, got %q", c.expected, value)
+		}
+	}
+
+	p = Prompt{Label: "Password", Mask: '*', VerticalKeyBehavior: VerticalHistoryNav}
+	p.Update(KeyPrev)
+	if value := p.editing.cur.Get(); value != "" {
+		t.Errorf("expected a masked prompt to ignore the history, got %q", value)
+	}
+}
+
+func TestPromptSelectAll(t *testing.T) {
+	cases := []struct {
+		name  string
+		keys  []rune
+		value string
+	}{
+		{"replaced by a character", []rune{KeySelectAll, 'x'}, "x"},
+		{"cleared by Backspace", []rune{KeySelectAll, KeyBackspace, 'x'}, "x"},
+		{"kept by Backward", []rune{KeySelectAll, KeyBackward, 'x'}, "xab"},
+		{"kept by Forward", []rune{KeySelectAll, KeyForward, 'x'}, "abx"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := Prompt{Label: "Name", AllowSelectAll: true, Pointer: PipeCursor}
+			p.Update('a')
+			p.Update('b')
+			for _, key := range c.keys {
+				p.Update(key)
+			}
+			if value := p.editing.cur.Get(); value != c.value {
+				t.Errorf("expected %q, got %q", c.value, value)
+			}
+		})
+	}
+
+	p := Prompt{Label: "Name", AllowSelectAll: true, Pointer: PipeCursor}
+	p.Update('a')
+	rendered, _, _, _ := p.Update(KeySelectAll)
+	if selected := Styler(BGBlue)("a") + "|"; !strings.HasSuffix(rendered, selected) {
+		t.Errorf("expected the input to be highlighted, got %q", rendered)
+	}
+
+	p = Prompt{Label: "Name", Pointer: PipeCursor}
+	p.Update('a')
+	p.Update(KeySelectAll)
+	p.Update('b')
+	if value := p.editing.cur.Get(); value != "ab" {
+		t.Errorf("expected KeySelectAll to be ignored without AllowSelectAll, got %q", value)
+	}
+}
+
+func TestPromptSuggestMany(t *testing.T) {
+	colors := []string{"red", "green", "blue"}
+	p := Prompt{
+		Label:     "Color",
+		Pointer:   PipeCursor,
+		Templates: &PromptTemplates{ValidationError: "{{ . }}"},
+		SuggestMany: func(input string) ([]string, error) {
+			for _, c := range colors {
+				if c == input {
+					return nil, nil
+				}
+			}
+			return []string{"green", "grey"}, errors.New("unknown color")
+		},
+	}
+
+	for _, key := range "gren" {
+		p.Update(key)
+	}
+	rendered, _, _, _ := p.Update(KeyEnter)
+	list := "\n" + iconSelect() + " green\n  " + Styler(FGFaint)("grey")
+	if rendered != "unknown color"+list {
+		t.Errorf("expected the suggestions under the error, got %q", rendered)
+	}
+
+	rendered, _, _, _ = p.Update(KeyNext)
+	list = "\n  " + Styler(FGFaint)("green") + "\n" + iconSelect() + " grey"
+	if rendered != "unknown color"+list {
+		t.Errorf("expected Down to pick the next suggestion, got %q", rendered)
+	}
+
+	p.Update(KeyPrev)
+	_, done, _, _ := p.Update(KeyEnter)
+	if value := p.editing.cur.Get(); done || value != "green" {
+		t.Errorf("expected Enter to apply the suggestion without submitting, got %q, done %v", value, done)
+	}
+
+	_, done, value, err := p.Update(KeyEnter)
+	if !done || value != "green" || err != nil {
+		t.Errorf("expected the fixed value to be submitted, got %q, %v", value, err)
+	}
+}
+
+func TestPromptDynamicDefault(t *testing.T) {
+	history := []string{"git status", "go test ./..."}
+	suggest := func(current string) string {
+		for _, h := range history {
+			if current != "" && strings.HasPrefix(h, current) {
+				return h
+			}
+		}
+		return ""
+	}
+
+	p := Prompt{Label: "Command", DynamicDefault: suggest, Pointer: PipeCursor}
+	rendered, _, _, _ := p.Update('g')
+	if !strings.HasSuffix(rendered, "g|"+Styler(FGFaint)("it status")) {
+		t.Errorf("expected the suggestion after the cursor, got %q", rendered)
+	}
+
+	rendered, _, _, _ = p.Update('o')
+	if !strings.HasSuffix(rendered, "go|"+Styler(FGFaint)(" test ./...")) {
+		t.Errorf("expected the suggestion to follow the input, got %q", rendered)
+	}
+
+	p.Update(KeyTab)
+	if value := p.editing.cur.Get(); value != "go test ./..." {
+		t.Errorf("expected Tab to accept the suggestion, got %q", value)
+	}
+
+	p = Prompt{Label: "Command", DynamicDefault: suggest, Pointer: PipeCursor}
+	p.Update('g')
+	p.Update('i')
+	p.Update(KeyBackward)
+	rendered, _, _, _ = p.Update(KeyForward)
+	if value := p.editing.cur.Get(); value != "gi" || !strings.HasSuffix(rendered, "gi|"+Styler(FGFaint)("t status")) {
+		t.Errorf("expected Forward to move to the end before accepting, got %q, %q", value, rendered)
+	}
+	p.Update(KeyForward)
+	if value := p.editing.cur.Get(); value != "git status" {
+		t.Errorf("expected Forward at the end to accept the suggestion, got %q", value)
+	}
+}
+
+func TestPromptMaxVisibleSuggestions(t *testing.T) {
+	p := Prompt{
+		Label:                 "Host",
+		Words:                 []string{"a1", "a2", "a3", "a4", "b1"},
+		MaxVisibleSuggestions: 2,
+		Pointer:               PipeCursor,
+	}
+
+	p.Update('a')
+	rendered, _, _, _ := p.Update(KeyTab)
+	faint := Styler(FGFaint)
+	list := "a1|\n" + iconSelect() + " a1\n  " + faint("a2") + "\n  " + faint("(+2 more)")
+	if !strings.HasSuffix(rendered, list) {
+		t.Errorf("expected the first 2 words listed, got %q", rendered)
+	}
+
+	p.Update(KeyNext)
+	rendered, _, _, _ = p.Update(KeyNext)
+	list = "a3|\n  " + faint("a2") + "\n" + iconSelect() + " a3\n  " + faint("(+2 more)")
+	if !strings.HasSuffix(rendered, list) {
+		t.Errorf("expected the list to scroll to the third word, got %q", rendered)
+	}
+
+	rendered, _, _, _ = p.Update(KeyPrev)
+	if !strings.Contains(rendered, "a2|\n"+iconSelect()+" a2\n  "+faint("a3")) {
+		t.Errorf("expected the previous word selected, got %q", rendered)
+	}
+
+	rendered, _, _, _ = p.Update('x')
+	if strings.Contains(rendered, "more") {
+		t.Errorf("expected the list to be hidden once typing, got %q", rendered)
+	}
+}
+
+func TestPromptTabBehavior(t *testing.T) {
+	tests := []struct {
+		name     string
+		behavior TabBehavior
+		words    []string
+		expected string
+	}{
+		{"complete", TabComplete, []string{"abc"}, "abc"},
+		{"complete without words", TabComplete, nil, "a\t"},
+		{"ignore", TabIgnore, []string{"abc"}, "a"},
+		{"literal", TabLiteral, []string{"abc"}, "a\t"},
+		{"spaces", TabInsertSpaces(2), []string{"abc"}, "a  "},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := Prompt{
+				Label:       "Name",
+				Words:       tc.words,
+				TabBehavior: tc.behavior,
+				Stdin:       strings.NewReader("a\t\r"),
+				Stdout:      &bytes.Buffer{},
+			}
+
+			value, err := p.Run()
+			if err != nil || value != tc.expected {
+				t.Errorf("expected %q, got %q, %v", tc.expected, value, err)
+			}
+
+			p = Prompt{Label: "Name", Words: tc.words, TabBehavior: tc.behavior}
+			for _, key := range []rune{'a', KeyTab} {
+				p.Update(key)
+			}
+			if _, _, value, err := p.Update(KeyEnter); err != nil || value != tc.expected {
+				t.Errorf("expected %q with Update, got %q, %v", tc.expected, value, err)
+			}
+		})
+	}
+}
+
+func TestPromptValidateOnce(t *testing.T) {
+	var calls []string
+	p := Prompt{
+		Label: "Name",
+		Validate: func(input string) error {
+			calls = append(calls, input)
+			return nil
+		},
+	}
+
+	for _, key := range []rune{'a', KeyBackward, KeyForward, 'b', 0} {
+		p.Update(key)
+	}
+
+	if got := strings.Join(calls, ","); got != ",a,ab" {
+		t.Errorf("expected each input to be validated once, got %q", got)
+	}
+}
+
+// lengthValidator limits the length of the input, counting the appended characters only.
+type lengthValidator struct {
+	max    int
+	length int
+	deltas []string
+}
+
+func (v *lengthValidator) Validate(input string) error {
+	v.length = len(input)
+	return v.check()
+}
+
+func (v *lengthValidator) ValidateDelta(prev, curr string) error {
+	v.deltas = append(v.deltas, curr[len(prev):])
+	v.length += len(curr) - len(prev)
+	return v.check()
+}
+
+func (v *lengthValidator) check() error {
+	if v.length > v.max {
+		return errors.New("too long")
+	}
+	return nil
+}
+
+func TestPromptValidateIncremental(t *testing.T) {
+	v := &lengthValidator{max: 3}
+	p := Prompt{
+		Label:               "Code",
+		Validate:            v.Validate,
+		ValidateIncremental: v,
+	}
+
+	for _, key := range "abcd" {
+		p.Update(key)
+	}
+	if got := strings.Join(v.deltas, ","); got != "a,b,c,d" {
+		t.Errorf("expected the appended characters to be validated, got %q", got)
+	}
+	if _, done, _, _ := p.Update(KeyEnter); done {
+		t.Errorf("expected the incremental error to reject the input")
+	}
+
+	p.Update(KeyBackspace)
+	if v.length != 3 || len(v.deltas) != 4 {
+		t.Errorf("expected a deletion to validate the whole input, got length %d", v.length)
+	}
+	if _, done, value, _ := p.Update(KeyEnter); !done || value != "abc" {
+		t.Errorf("expected abc to be submitted, got %q", value)
+	}
+}
+
+func TestPromptValidationTrace(t *testing.T) {
+	c := newFakeClock()
+	tooShort := errors.New("too short")
+
+	var trace []ValidationRecord
+	p := Prompt{
+		Label:           "Name",
+		ValidationTrace: &trace,
+		Validate: func(input string) error {
+			if len(input) < 2 {
+				return tooShort
+			}
+			return nil
+		},
+		clock: c,
+	}
+
+	p.Update('a')
+	c.Advance(time.Second)
+	p.Update(KeyBackward)
+	p.Update('b')
+
+	expected := []ValidationRecord{
+		{Input: "", Err: tooShort, Time: c.now.Add(-time.Second)},
+		{Input: "a", Err: tooShort, Time: c.now.Add(-time.Second)},
+		{Input: "ba", Time: c.now},
+	}
+	if len(trace) != len(expected) {
+		t.Fatalf("expected %d validations, got %+v", len(expected), trace)
+	}
+	for i, r := range trace {
+		if r != expected[i] {
+			t.Errorf("expected validation %d to be %+v, got %+v", i, expected[i], r)
+		}
+	}
+}
+
+func TestPromptFilterInput(t *testing.T) {
+	// numbers up to 255, like the parts of an IPv4 address.
+	p := Prompt{
+		Label: "Octet",
+		FilterInput: func(current string, candidate rune) bool {
+			n, err := strconv.Atoi(current + string(candidate))
+			return err == nil && n <= 255
+		},
+	}
+
+	for _, key := range "2a59" {
+		p.Update(key)
+	}
+
+	if value := p.editing.cur.Get(); value != "25" {
+		t.Errorf("expected the rejected runes to be dropped, got %q", value)
+	}
+}
+
+func TestPromptMaskedDefault(t *testing.T) {
+	t.Run("masks the default", func(t *testing.T) {
+		p := Prompt{Label: "Token", Default: "abc", Mask: '*'}
+
+		rendered, _, _, _ := p.Update(0)
+		if strings.Contains(rendered, "abc") {
+			t.Errorf("expected the default to be masked, got %q", rendered)
+		}
+	})
+
+	t.Run("reveals the default until a key is pressed", func(t *testing.T) {
+		p := Prompt{
+			Label:         "Token",
+			Default:       "abc",
+			AllowEdit:     true,
+			Mask:          '*',
+			MaskedDefault: MaskedDefaultReveal,
+			Pointer:       PipeCursor,
+		}
+
+		rendered, _, _, _ := p.Update(0)
+		if !strings.HasSuffix(rendered, " abc|") {
+			t.Errorf("expected the default in clear text, got %q", rendered)
+		}
+
+		rendered, _, _, _ = p.Update('d')
+		if !strings.HasSuffix(rendered, " ****|") {
+			t.Errorf("expected the edited input to be masked, got %q", rendered)
+		}
+	})
+
+	t.Run("omits the default", func(t *testing.T) {
+		p := Prompt{
+			Label:         "Token",
+			Default:       "abc",
+			Mask:          '*',
+			MaskedDefault: MaskedDefaultOmit,
+			Pointer:       PipeCursor,
+		}
+
+		rendered, _, _, _ := p.Update(0)
+		if !strings.HasSuffix(rendered, "\x1b[0m |") {
+			t.Errorf("expected an empty input, got %q", rendered)
+		}
+
+		_, done, value, err := p.Update(KeyEnter)
+		if !done || err != nil || value != "abc" {
+			t.Errorf("expected the default to be submitted, got %q, %v, %v", value, done, err)
+		}
+	})
+}
+
+func TestPromptSoftRequired(t *testing.T) {
+	p := Prompt{
+		Label:        "Name",
+		SoftRequired: true,
+		Pointer:      PipeCursor,
+		Templates:    &PromptTemplates{ValidationError: "error"},
+	}
+
+	rendered, done, _, _ := p.Update(KeyEnter)
+	if done || !strings.HasSuffix(rendered, "|\x1b[2m (required)\x1b[0m") {
+		t.Fatalf("expected the required hint after the input, got %q, %v", rendered, done)
+	}
+
+	rendered, _, _, _ = p.Update('a')
+	if strings.Contains(rendered, "required") {
+		t.Errorf("expected the hint to be hidden once typing, got %q", rendered)
+	}
+
+	_, done, value, _ := p.Update(KeyEnter)
+	if !done || value != "a" {
+		t.Errorf("expected a to be submitted, got %q, %v", value, done)
+	}
+}
+
+func TestPromptDoubleConfirm(t *testing.T) {
+	p := Prompt{Label: "Delete", DoubleConfirm: true, Pointer: PipeCursor}
+	hint := Styler(FGFaint)(" (press Enter again to confirm)")
+
+	p.Update('x')
+	rendered, done, _, _ := p.Update(KeyEnter)
+	if done || !strings.HasSuffix(rendered, "x|"+hint) {
+		t.Fatalf("expected the first Enter to ask for confirmation, got %q, %v", rendered, done)
+	}
+
+	rendered, _, _, _ = p.Update('y')
+	if strings.Contains(rendered, "again") {
+		t.Errorf("expected another key to cancel the submission, got %q", rendered)
+	}
+	if _, done, _, _ := p.Update(KeyEnter); done {
+		t.Errorf("expected the confirmation to be asked again")
+	}
+
+	if _, done, value, _ := p.Update(KeyEnter); !done || value != "xy" {
+		t.Errorf("expected the second Enter to submit xy, got %q, %v", value, done)
+	}
+}
+
+func TestPromptControlChars(t *testing.T) {
+	p := Prompt{Label: "Name", Default: "ab"}
+	p.Update(0)
+
+	p.editing.listen([]rune{'\x07'}, 0, '\x07')
+	p.editing.listen([]rune{'\x00'}, 0, '\x00')
+	if value := p.editing.cur.Get(); value != "ab" {
+		t.Errorf("expected the control characters to be dropped, got %q", value)
+	}
+
+	p = Prompt{Label: "Name"}
+	p.Update(0)
+	p.editing.listen([]rune{'c', '\x1b', '\t', 'd'}, 0, 'd')
+	if value := p.editing.cur.Get(); value != "c\td" {
+		t.Errorf("expected the printable characters and whitespace to be kept, got %q", value)
+	}
+
+	p = Prompt{Label: "Name", AllowControlChars: true}
+	p.Update(0)
+	p.editing.listen([]rune{'\x07'}, 0, '\x07')
+	if value := p.editing.cur.Get(); value != "\x07" {
+		t.Errorf("expected the control character to be inserted, got %q", value)
+	}
+}
+
+func TestPromptEditorLaunch(t *testing.T) {
+	if _, err := exec.LookPath("sed"); err != nil {
+		t.Skip("sed is needed to edit the input")
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("TMPDIR", t.TempDir())
+
+	t.Setenv("EDITOR", "sed -i.bak s/a/XY/")
+	p := Prompt{Label: "Message", AllowEditorLaunch: true}
+	p.Update('a')
+	p.Update(KeyEditor)
+	if value := p.editing.cur.Get(); value != "XY" {
+		t.Errorf("expected the edited input, got %q", value)
+	}
+
+	t.Setenv("EDITOR", "false")
+	p = Prompt{Label: "Message", AllowEditorLaunch: true, Templates: &PromptTemplates{ValidationError: "{{ . }}"}}
+	p.Update('a')
+	rendered, _, _, _ := p.Update(KeyEditor)
+	if value := p.editing.cur.Get(); value != "a" || !strings.HasPrefix(rendered, "false: ") {
+		t.Errorf("expected the input to be kept and the error displayed, got %q, %q", value, rendered)
+	}
+}
+
+func TestPromptOnChange(t *testing.T) {
+	t.Run("reports every change", func(t *testing.T) {
+		var changes []string
+		p := Prompt{Label: "Name", OnChange: func(s string) { changes = append(changes, s) }}
+
+		for _, key := range []rune{'a', 'b', KeyBackward, KeyBackspace} {
+			p.Update(key)
+		}
+
+		if got := strings.Join(changes, ","); got != "a,ab,b" {
+			t.Errorf("expected changes a,ab,b, got %s", got)
+		}
+	})
+
+	t.Run("debounces changes", func(t *testing.T) {
+		changes := make(chan string, 3)
+		p := Prompt{
+			Label:          "Name",
+			OnChange:       func(s string) { changes <- s },
+			ChangeDebounce: 20 * time.Millisecond,
+		}
+
+		for _, key := range "abc" {
+			p.Update(key)
+		}
+
+		select {
+		case value := <-changes:
+			if value != "abc" {
+				t.Errorf("expected a single change with abc, got %q", value)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected OnChange to be called")
+		}
+
+		select {
+		case value := <-changes:
+			t.Errorf("expected the other changes to be dropped, got %q", value)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package promptui
+
+import (
+	"io"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// loneEscape stands for a lone ESC of the input. Readline takes any ESC for the start of an escape sequence and
+// drops it with the key after it, so escapeReader reads a lone ESC as this rune instead, which the prompt handles
+// as the ESC key.
+const loneEscape = '\uE01B'
+
+// escapeReader replaces the lone ESC keys of the input with loneEscape while it is enabled. Terminals send each
+// escape sequence at once, so an ESC ending a read is lone. In vim mode, so is an ESC followed by a key that
+// doesn't start the sequences of keys like the arrows, as typing ESC and a key of the normal mode quickly does;
+// otherwise they are left to readline, as terminals send the Alt combinations that way. The line editor of a
+// Session reads from it from another goroutine, so it is set up again for each of its prompts.
+type escapeReader struct {
+	r       io.Reader
+	buf     []byte
+	pending []byte
+	err     error
+	enabled atomic.Bool
+	vim     atomic.Bool
+}
+
+func newEscapeReader(r io.Reader, vim bool) *escapeReader {
+	e := &escapeReader{r: r, buf: make([]byte, 4096)}
+	e.set(true, vim)
+	return e
+}
+
+// set enables the reader, in vim mode when vim is set.
+func (e *escapeReader) set(enabled, vim bool) {
+	e.enabled.Store(enabled)
+	e.vim.Store(vim)
+}
+
+func (e *escapeReader) Read(b []byte) (int, error) {
+	if len(e.pending) == 0 && e.err == nil {
+		n, err := e.r.Read(e.buf)
+		e.pending, e.err = e.pending[:0], err
+
+		in := e.buf[:n]
+		enabled, vim := e.enabled.Load(), e.vim.Load()
+		for i, c := range in {
+			last := i+1 == len(in)
+			if enabled && c == '\x1b' && (last || (vim && in[i+1] != '[' && in[i+1] != 'O')) {
+				e.pending = utf8.AppendRune(e.pending, loneEscape)
+				continue
+			}
+			e.pending = append(e.pending, c)
+		}
+	}
+	if len(e.pending) == 0 {
+		return 0, e.err
+	}
+
+	n := copy(b, e.pending)
+	e.pending = e.pending[n:]
+	return n, nil
+}
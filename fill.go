@@ -0,0 +1,144 @@
+package promptui
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FillStruct fills the struct pointed to by ptr by running a prompt for each of its exported fields with a
+// prompt tag, in the order of the fields. The tags of a field configure its prompt:
+//
+//	prompt    the label of the prompt, required for the field to be filled
+//	default   the default value
+//	mask      the rune hiding the input
+//	validate  a regular expression the input must match
+//
+// String fields receive the input as is, integer fields only accept numbers and bool fields are filled with a
+// confirm prompt, whose default is set by a default tag of "y" or "true". FillStruct stops at the first error,
+// like an interrupt, leaving the remaining fields untouched. Each prompt displays its position among the fields,
+// as with RunSteps.
+//
+//	type signup struct {
+//		Name     string `prompt:"Name" validate:"^[a-z]+$"`
+//		Age      int    `prompt:"Age" default:"18"`
+//		Password string `prompt:"Password" mask:"*"`
+//		Admin    bool   `prompt:"Admin"`
+//	}
+//
+//	var s signup
+//	err := promptui.FillStruct(&s)
+func FillStruct(ptr interface{}) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("promptui: FillStruct expects a pointer to a struct, got %T", ptr)
+	}
+	v = v.Elem()
+
+	var fields []int
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if _, ok := field.Tag.Lookup("prompt"); ok && field.IsExported() {
+			fields = append(fields, i)
+		}
+	}
+
+	for step, i := range fields {
+		field := v.Type().Field(i)
+
+		p, err := fieldPrompt(field)
+		if err != nil {
+			return err
+		}
+		p.Step = StepInfo{Index: step + 1, Total: len(fields)}
+
+		if field.Type.Kind() == reflect.Bool {
+			accepted, err := p.RunConfirm()
+			if err != nil {
+				return err
+			}
+			v.Field(i).SetBool(accepted)
+			continue
+		}
+
+		value, err := p.Run()
+		if err != nil {
+			return err
+		}
+
+		err = setField(v.Field(i), value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldPrompt builds the prompt filling field from its tags.
+func fieldPrompt(field reflect.StructField) (*Prompt, error) {
+	p := &Prompt{
+		Label:   field.Tag.Get("prompt"),
+		Default: field.Tag.Get("default"),
+	}
+
+	if mask := field.Tag.Get("mask"); mask != "" {
+		p.Mask, _ = utf8.DecodeRuneInString(mask)
+	}
+
+	var re *regexp.Regexp
+	if expr := field.Tag.Get("validate"); expr != "" {
+		var err error
+		re, err = regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("promptui: invalid validate tag of field %s: %w", field.Name, err)
+		}
+	}
+
+	switch field.Type.Kind() {
+	case reflect.Bool:
+		if accepted, _ := strconv.ParseBool(p.Default); accepted || strings.ToLower(p.Default) == "y" {
+			p.Default = "y"
+		}
+		return p, nil
+	case reflect.String:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	default:
+		return nil, fmt.Errorf("promptui: unsupported type %s of field %s", field.Type, field.Name)
+	}
+
+	isInt := field.Type.Kind() != reflect.String
+
+	p.Validate = func(input string) error {
+		if re != nil && !re.MatchString(input) {
+			return fmt.Errorf("must match %s", re)
+		}
+		if isInt {
+			if _, err := strconv.ParseInt(input, 10, field.Type.Bits()); err != nil {
+				return errors.New("must be a number")
+			}
+		}
+		return nil
+	}
+
+	return p, nil
+}
+
+// setField assigns the input of a prompt to a string or integer field.
+func setField(field reflect.Value, value string) error {
+	if field.Kind() == reflect.String {
+		field.SetString(value)
+		return nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+	if err != nil {
+		return err
+	}
+	field.SetInt(n)
+	return nil
+}
@@ -0,0 +1,73 @@
+package promptui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldPrompt(t *testing.T) {
+	type signup struct {
+		Name     string  `prompt:"Name" validate:"^[a-z]+$"`
+		Age      int8    `prompt:"Age" default:"18"`
+		Password string  `prompt:"Password" mask:"*"`
+		Admin    bool    `prompt:"Admin" default:"true"`
+		Ratio    float64 `prompt:"Ratio"`
+	}
+
+	typ := reflect.TypeOf(signup{})
+	prompt := func(name string) *Prompt {
+		field, _ := typ.FieldByName(name)
+		p, err := fieldPrompt(field)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", name, err)
+		}
+		return p
+	}
+
+	t.Run("validates strings with a regular expression", func(t *testing.T) {
+		p := prompt("Name")
+		if p.Label != "Name" || p.Validate("john") != nil || p.Validate("John") == nil {
+			t.Errorf("expected only lowercase names to be valid")
+		}
+	})
+
+	t.Run("validates integers", func(t *testing.T) {
+		p := prompt("Age")
+		if p.Default != "18" || p.Validate("42") != nil || p.Validate("old") == nil || p.Validate("300") == nil {
+			t.Errorf("expected only numbers fitting an int8 to be valid")
+		}
+	})
+
+	t.Run("masks", func(t *testing.T) {
+		if p := prompt("Password"); p.Mask != '*' {
+			t.Errorf("expected the * mask, got %q", p.Mask)
+		}
+	})
+
+	t.Run("confirms bools", func(t *testing.T) {
+		if p := prompt("Admin"); p.Default != "y" {
+			t.Errorf("expected a default of y, got %q", p.Default)
+		}
+	})
+
+	t.Run("rejects unsupported types", func(t *testing.T) {
+		field, _ := typ.FieldByName("Ratio")
+		if _, err := fieldPrompt(field); err == nil {
+			t.Errorf("expected an error for float fields")
+		}
+	})
+}
+
+func TestFillStruct(t *testing.T) {
+	var s string
+	if err := FillStruct(&s); err == nil {
+		t.Errorf("expected an error for a pointer to a string")
+	}
+
+	var v struct {
+		Name string `prompt:"Name"`
+	}
+	if err := FillStruct(v); err == nil {
+		t.Errorf("expected an error for a struct passed by value")
+	}
+}
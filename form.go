@@ -0,0 +1,39 @@
+package promptui
+
+import "strings"
+
+// FormLayout aligns the labels of prompts displayed one after the other, like the fields of a form, by
+// padding each label to the display width of the longest one. With the default templates, the colons and
+// inputs of all the prompts end up in the same column.
+type FormLayout struct {
+	width int
+}
+
+// NewFormLayout creates a FormLayout fitting the given labels.
+func NewFormLayout(labels ...string) FormLayout {
+	var l FormLayout
+	for _, label := range labels {
+		if w := DisplayWidth(label); w > l.width {
+			l.width = w
+		}
+	}
+	return l
+}
+
+// Pad returns the label followed by the spaces needed to align it with the other labels of the layout.
+func (l FormLayout) Pad(label string) string {
+	n := l.width - DisplayWidth(label)
+	if n <= 0 {
+		return label
+	}
+	return label + strings.Repeat(" ", n)
+}
+
+// Apply pads the labels of the given prompts. Prompts using a non string label are left untouched.
+func (l FormLayout) Apply(prompts ...*Prompt) {
+	for _, p := range prompts {
+		if label, ok := p.Label.(string); ok {
+			p.Label = l.Pad(label)
+		}
+	}
+}
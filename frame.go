@@ -0,0 +1,82 @@
+package promptui
+
+import "strings"
+
+// Border is the set of runes drawing the border of a Frame.
+type Border struct {
+	TopLeft, TopRight, BottomLeft, BottomRight rune
+	Horizontal, Vertical                       rune
+}
+
+var (
+	// BorderASCII draws the border with ASCII characters, for terminals without unicode support.
+	BorderASCII = Border{
+		TopLeft: '+', TopRight: '+', BottomLeft: '+', BottomRight: '+',
+		Horizontal: '-', Vertical: '|',
+	}
+
+	// BorderRounded draws the border with unicode box drawing characters and rounded corners.
+	BorderRounded = Border{
+		TopLeft: '╭', TopRight: '╮', BottomLeft: '╰', BottomRight: '╯',
+		Horizontal: '─', Vertical: '│',
+	}
+)
+
+// Frame draws a box around a prompt while it's running. The box is erased once the prompt has ended, leaving
+// the success line only.
+type Frame struct {
+	// Border is the style of the border. If empty, BorderRounded is used on terminals supporting unicode and
+	// BorderASCII on the others.
+	Border Border
+
+	// Title is an optional text displayed in the top border.
+	Title string
+
+	// Width is the minimum number of columns inside the box, which otherwise grows with the input.
+	Width int
+}
+
+// draw returns the lines of content inside the box.
+func (f *Frame) draw(content string) string {
+	b := f.Border
+	if b == (Border{}) {
+		b = BorderASCII
+		if supportsUnicode() {
+			b = BorderRounded
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+
+	width := f.Width
+	for _, line := range lines {
+		if w := DisplayWidth(line); w > width {
+			width = w
+		}
+	}
+	if f.Title != "" && DisplayWidth(f.Title)+2 > width {
+		width = DisplayWidth(f.Title) + 2
+	}
+
+	// the content is padded by a space on each side.
+	horizontal := string(b.Horizontal)
+	var sb strings.Builder
+
+	sb.WriteRune(b.TopLeft)
+	if f.Title != "" {
+		sb.WriteString(horizontal + " " + f.Title + " ")
+		sb.WriteString(strings.Repeat(horizontal, width-DisplayWidth(f.Title)-1))
+	} else {
+		sb.WriteString(strings.Repeat(horizontal, width+2))
+	}
+	sb.WriteRune(b.TopRight)
+
+	for _, line := range lines {
+		sb.WriteString("\n" + string(b.Vertical) + " " + line)
+		sb.WriteString(strings.Repeat(" ", width-DisplayWidth(line)))
+		sb.WriteString(" " + string(b.Vertical))
+	}
+
+	sb.WriteString("\n" + string(b.BottomLeft) + strings.Repeat(horizontal, width+2) + string(b.BottomRight))
+	return sb.String()
+}
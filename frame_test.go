@@ -0,0 +1,50 @@
+package promptui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFrameDraw(t *testing.T) {
+	f := &Frame{Border: BorderASCII, Title: "Login"}
+
+	got := f.draw("Name: \x1b[1mjo\x1b[0m\nlonger line")
+	want := strings.Join([]string{
+		"+- Login -----+",
+		"| Name: \x1b[1mjo\x1b[0m    |",
+		"| longer line |",
+		"+-------------+",
+	}, "\n")
+	if got != want {
+		t.Errorf("expected the box\n%s\ngot\n%s", want, got)
+	}
+
+	f = &Frame{Border: BorderRounded, Title: "A long title", Width: 2}
+	if got := f.draw("x"); !strings.HasPrefix(got, "╭─ A long title ─╮\n│ x              │\n") {
+		t.Errorf("expected the box to fit the title, got\n%s", got)
+	}
+}
+
+func TestPromptFrame(t *testing.T) {
+	p := Prompt{
+		Label:     "Name",
+		Frame:     &Frame{Border: BorderASCII, Width: 10},
+		Templates: &PromptTemplates{Prompt: "{{ . }}: ", ValidationError: "bad"},
+		Validate: func(input string) error {
+			if input == "" {
+				return ErrAbort
+			}
+			return nil
+		},
+	}
+
+	rendered, _, _, _ := p.Update(0)
+	if lines := strings.Split(rendered, "\n"); len(lines) != 3 || lines[0] != "+------------+" {
+		t.Errorf("expected the prompt inside the box, got %q", rendered)
+	}
+
+	rendered, _, _, _ = p.Update(KeyEnter)
+	if rendered != "+------------+\n| bad        |\n+------------+" {
+		t.Errorf("expected the error inside the box, got %q", rendered)
+	}
+}
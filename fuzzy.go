@@ -0,0 +1,66 @@
+package promptui
+
+import "unicode"
+
+// Scores awarded by FuzzyMatch. A matched rune is always worth fuzzyMatchScore, with an additional bonus
+// when it starts the candidate, directly follows the previous match or starts a new word.
+const (
+	fuzzyMatchScore      = 1
+	fuzzyPrefixBonus     = 8
+	fuzzyContiguousBonus = 4
+	fuzzyBoundaryBonus   = 2
+)
+
+// FuzzyMatch reports whether all the runes of pattern appear in candidate in the same order, ignoring
+// case. When they do, the returned score can be used to rank candidates against each other: higher
+// scores are better matches. Prefix and contiguous matches score higher than scattered ones, and shorter
+// candidates are preferred over longer ones matching the same runes.
+//
+// An empty pattern matches every candidate with a score of 0.
+func FuzzyMatch(pattern, candidate string) (score int, matched bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, true
+	}
+
+	c := []rune(candidate)
+	pi := 0
+	prev := -2
+
+	for ci, r := range c {
+		if pi == len(p) {
+			break
+		}
+
+		if unicode.ToLower(r) != unicode.ToLower(p[pi]) {
+			continue
+		}
+
+		score += fuzzyMatchScore
+
+		switch {
+		case ci == 0:
+			score += fuzzyPrefixBonus
+		case ci == prev+1:
+			score += fuzzyContiguousBonus
+		case isWordBoundary(c[ci-1]):
+			score += fuzzyBoundaryBonus
+		}
+
+		prev = ci
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, false
+	}
+
+	// every unmatched rune in the candidate costs a point so that tighter matches rank first.
+	score -= len(c) - len(p)
+
+	return score, true
+}
+
+func isWordBoundary(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
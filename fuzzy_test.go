@@ -0,0 +1,44 @@
+package promptui
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	t.Run("matches runes in order ignoring case", func(t *testing.T) {
+		for _, c := range []struct{ pattern, candidate string }{
+			{"", "anything"},
+			{"abc", "abc"},
+			{"ABC", "a-b-c"},
+			{"gco", "git checkout"},
+		} {
+			if _, ok := FuzzyMatch(c.pattern, c.candidate); !ok {
+				t.Errorf("expected %q to match %q", c.pattern, c.candidate)
+			}
+		}
+	})
+
+	t.Run("rejects missing or out of order runes", func(t *testing.T) {
+		for _, c := range []struct{ pattern, candidate string }{
+			{"abc", "ab"},
+			{"cba", "abc"},
+			{"x", ""},
+		} {
+			if _, ok := FuzzyMatch(c.pattern, c.candidate); ok {
+				t.Errorf("expected %q not to match %q", c.pattern, c.candidate)
+			}
+		}
+	})
+
+	t.Run("favors prefix and contiguous matches", func(t *testing.T) {
+		prefix, _ := FuzzyMatch("ser", "server")
+		inner, _ := FuzzyMatch("ser", "observer")
+		if prefix <= inner {
+			t.Errorf("expected prefix score %d to be greater than %d", prefix, inner)
+		}
+
+		contiguous, _ := FuzzyMatch("ser", "xserve")
+		scattered, _ := FuzzyMatch("ser", "xsuper")
+		if contiguous <= scattered {
+			t.Errorf("expected contiguous score %d to be greater than %d", contiguous, scattered)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package promptui
+
+import (
+	"maps"
+	"text/template"
+)
+
+// Globals holds the package-level settings shared by every prompt and select of a program: the icons, the
+// template helpers and the keys. See Defaults and ResetGlobals.
+type Globals struct {
+	IconInitial string
+	IconGood    string
+	IconWarn    string
+	IconBad     string
+	IconSelect  string
+	Icons       IconSet
+
+	SearchPrompt string
+	FuncMap      template.FuncMap
+
+	// SemanticColors are the styles of the semantic template helpers, changed with SetSemanticColor.
+	SemanticColors map[string]func(interface{}) string
+
+	KeyEnter     rune
+	KeyCtrlH     rune
+	KeyBackspace rune
+	KeyPrev      rune
+	KeyNext      rune
+	KeyBackward  rune
+	KeyForward   rune
+	KeyLineStart rune
+	KeyLineEnd   rune
+	KeyTab       rune
+	KeyEditor    rune
+	KeySelectAll rune
+
+	KeyPrevDisplay     string
+	KeyNextDisplay     string
+	KeyBackwardDisplay string
+	KeyForwardDisplay  string
+}
+
+// defaults are the settings the package starts with.
+var defaults = currentGlobals()
+
+// Defaults returns the values the package-level settings start with, before a program changes them.
+func Defaults() Globals {
+	g := defaults
+	g.FuncMap = maps.Clone(defaults.FuncMap)
+	g.SemanticColors = maps.Clone(defaults.SemanticColors)
+	return g
+}
+
+// ResetGlobals restores the package-level settings to their Defaults and forgets the SessionSuggestions. It is
+// intended for tests changing the settings, so they don't depend on the order they run in, and must not be
+// called while prompts are running.
+func ResetGlobals() {
+	g := Defaults()
+
+	IconInitial, IconGood, IconWarn, IconBad, IconSelect = g.IconInitial, g.IconGood, g.IconWarn, g.IconBad, g.IconSelect
+	Icons = g.Icons
+
+	SearchPrompt = g.SearchPrompt
+	FuncMap = g.FuncMap
+	semanticColors = g.SemanticColors
+
+	KeyEnter, KeyCtrlH, KeyBackspace = g.KeyEnter, g.KeyCtrlH, g.KeyBackspace
+	KeyPrev, KeyNext, KeyBackward, KeyForward = g.KeyPrev, g.KeyNext, g.KeyBackward, g.KeyForward
+	KeyLineStart, KeyLineEnd, KeyTab, KeyEditor, KeySelectAll = g.KeyLineStart, g.KeyLineEnd, g.KeyTab, g.KeyEditor, g.KeySelectAll
+	KeyPrevDisplay, KeyNextDisplay = g.KeyPrevDisplay, g.KeyNextDisplay
+	KeyBackwardDisplay, KeyForwardDisplay = g.KeyBackwardDisplay, g.KeyForwardDisplay
+
+	ClearSessionSuggestions()
+}
+
+// currentGlobals returns the current package-level settings, copying the maps.
+func currentGlobals() Globals {
+	return Globals{
+		IconInitial: IconInitial,
+		IconGood:    IconGood,
+		IconWarn:    IconWarn,
+		IconBad:     IconBad,
+		IconSelect:  IconSelect,
+		Icons:       Icons,
+
+		SearchPrompt:   SearchPrompt,
+		FuncMap:        maps.Clone(FuncMap),
+		SemanticColors: maps.Clone(semanticColors),
+
+		KeyEnter:     KeyEnter,
+		KeyCtrlH:     KeyCtrlH,
+		KeyBackspace: KeyBackspace,
+		KeyPrev:      KeyPrev,
+		KeyNext:      KeyNext,
+		KeyBackward:  KeyBackward,
+		KeyForward:   KeyForward,
+		KeyLineStart: KeyLineStart,
+		KeyLineEnd:   KeyLineEnd,
+		KeyTab:       KeyTab,
+		KeyEditor:    KeyEditor,
+		KeySelectAll: KeySelectAll,
+
+		KeyPrevDisplay:     KeyPrevDisplay,
+		KeyNextDisplay:     KeyNextDisplay,
+		KeyBackwardDisplay: KeyBackwardDisplay,
+		KeyForwardDisplay:  KeyForwardDisplay,
+	}
+}
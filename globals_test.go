@@ -0,0 +1,33 @@
+package promptui
+
+import "testing"
+
+func TestResetGlobals(t *testing.T) {
+	defer ResetGlobals()
+
+	IconGood = "+"
+	KeyEnter = 'x'
+	FuncMap["shout"] = func(s string) string { return s + "!" }
+	SetSemanticColor("primary", FGMagenta)
+	rememberValue("db.local")
+
+	ResetGlobals()
+
+	if IconGood != Defaults().IconGood || KeyEnter != Defaults().KeyEnter {
+		t.Errorf("expected the icons and keys to be restored, got %q and %q", IconGood, KeyEnter)
+	}
+	if _, ok := FuncMap["shout"]; ok {
+		t.Error("expected the added template helper to be removed")
+	}
+	if s, expected := semanticColors["primary"]("hi"), Styler(FGCyan)("hi"); s != expected {
+		t.Errorf("expected the semantic colors to be restored, got %q", s)
+	}
+	if len(SessionSuggestions()) != 0 {
+		t.Errorf("expected the session suggestions to be forgotten, got %v", SessionSuggestions())
+	}
+
+	Defaults().FuncMap["shout"] = nil
+	if _, ok := Defaults().FuncMap["shout"]; ok {
+		t.Error("expected Defaults to return a copy of the template helpers")
+	}
+}
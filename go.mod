@@ -2,9 +2,9 @@ module github.com/sohomdatta1/promptui
 
 go 1.22
 
-require github.com/ergochat/readline v0.1.2-0.20240515053957-087affdc83e9
-
 require (
-	golang.org/x/sys v0.20.0 // indirect
-	golang.org/x/text v0.15.0 // indirect
+	github.com/ergochat/readline v0.1.2-0.20240515053957-087affdc83e9
+	golang.org/x/text v0.15.0
 )
+
+require golang.org/x/sys v0.20.0 // indirect
@@ -0,0 +1,85 @@
+package promptui
+
+import (
+	"os"
+	"strings"
+)
+
+// IconSet selects the kind of icons used by the default templates.
+type IconSet int
+
+const (
+	// IconsAuto uses Unicode icons when the terminal seems to support them and ASCII icons otherwise.
+	IconsAuto IconSet = iota
+
+	// IconsUnicode always uses Unicode icons.
+	IconsUnicode
+
+	// IconsASCII always uses ASCII icons.
+	IconsASCII
+)
+
+// Icons sets which icons are used by the default templates. With IconsAuto, the default, support for Unicode
+// is detected from the TERM and locale (LC_ALL, LC_CTYPE, LANG) environment variables. It can be set to
+// IconsUnicode for terminals known to display Unicode correctly despite what their environment says.
+//
+// Only icons that still hold their default value are affected, customized icons are always used as is.
+var Icons = IconsAuto
+
+var (
+	unicodeIconGood   = Styler(FGGreen)("✔")
+	unicodeIconWarn   = Styler(FGYellow)("⚠")
+	unicodeIconBad    = Styler(FGRed)("✗")
+	unicodeIconSelect = Styler(FGBold)("▸")
+
+	asciiIconGood   = Styler(FGGreen)("v")
+	asciiIconWarn   = Styler(FGYellow)("!")
+	asciiIconBad    = Styler(FGRed)("x")
+	asciiIconSelect = Styler(FGBold)(">")
+)
+
+func iconGood() string {
+	return pickIcon(IconGood, unicodeIconGood, asciiIconGood)
+}
+
+func iconBad() string {
+	return pickIcon(IconBad, unicodeIconBad, asciiIconBad)
+}
+
+func iconSelect() string {
+	return pickIcon(IconSelect, unicodeIconSelect, asciiIconSelect)
+}
+
+// pickIcon returns the variant of a default icon supported by the terminal, or the icon itself if it has
+// been customized.
+func pickIcon(icon, unicode, ascii string) string {
+	if icon != unicode && icon != ascii {
+		return icon
+	}
+
+	if supportsUnicode() {
+		return unicode
+	}
+	return ascii
+}
+
+func supportsUnicode() bool {
+	switch Icons {
+	case IconsUnicode:
+		return true
+	case IconsASCII:
+		return false
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := strings.ToLower(os.Getenv(name)); v != "" {
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+
+	return unicodeByDefault()
+}
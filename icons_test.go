@@ -0,0 +1,54 @@
+package promptui
+
+import "testing"
+
+func TestPickIcon(t *testing.T) {
+	defer func(icons IconSet) { Icons = icons }(Icons)
+
+	t.Run("detects unicode support from the locale", func(t *testing.T) {
+		Icons = IconsAuto
+		t.Setenv("TERM", "xterm")
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_CTYPE", "")
+
+		t.Setenv("LANG", "en_US.UTF-8")
+		if icon := pickIcon(unicodeIconGood, unicodeIconGood, asciiIconGood); icon != unicodeIconGood {
+			t.Errorf("expected unicode icon, got %q", icon)
+		}
+
+		t.Setenv("LANG", "C")
+		if icon := pickIcon(unicodeIconGood, unicodeIconGood, asciiIconGood); icon != asciiIconGood {
+			t.Errorf("expected ascii icon, got %q", icon)
+		}
+	})
+
+	t.Run("falls back to ascii on dumb terminals", func(t *testing.T) {
+		Icons = IconsAuto
+		t.Setenv("TERM", "dumb")
+		t.Setenv("LANG", "en_US.UTF-8")
+		if icon := pickIcon(unicodeIconBad, unicodeIconBad, asciiIconBad); icon != asciiIconBad {
+			t.Errorf("expected ascii icon, got %q", icon)
+		}
+	})
+
+	t.Run("can be forced", func(t *testing.T) {
+		t.Setenv("TERM", "dumb")
+		Icons = IconsUnicode
+		if icon := pickIcon(asciiIconBad, unicodeIconBad, asciiIconBad); icon != unicodeIconBad {
+			t.Errorf("expected unicode icon, got %q", icon)
+		}
+
+		Icons = IconsASCII
+		if icon := pickIcon(unicodeIconBad, unicodeIconBad, asciiIconBad); icon != asciiIconBad {
+			t.Errorf("expected ascii icon, got %q", icon)
+		}
+	})
+
+	t.Run("keeps customized icons", func(t *testing.T) {
+		Icons = IconsASCII
+		custom := Styler(FGGreen)("OK")
+		if icon := pickIcon(custom, unicodeIconGood, asciiIconGood); icon != custom {
+			t.Errorf("expected custom icon, got %q", icon)
+		}
+	})
+}
@@ -26,4 +26,21 @@ var (
 	// KeyForward is the default key to page down during selection.
 	KeyForward        rune = readline.CharForward
 	KeyForwardDisplay      = "→"
+
+	// KeyLineStart is the key moving the cursor to the start of the input in prompt mode.
+	KeyLineStart rune = readline.CharLineStart
+
+	// KeyLineEnd is the key moving the cursor to the end of the input in prompt mode.
+	KeyLineEnd rune = readline.CharLineEnd
+
+	// KeyTab is the key used for completion in prompt mode.
+	KeyTab rune = readline.CharTab
+
+	// KeyEditor is the key opening the input in an editor in prompt mode, when AllowEditorLaunch is set. It
+	// defaults to <Ctrl-O>.
+	KeyEditor rune = 15
+
+	// KeySelectAll is the key selecting the whole input in prompt mode, when AllowSelectAll is set. It defaults to
+	// <Ctrl-X>, since <Ctrl-A> is KeyLineStart, which can be remapped to KeySelectAll if preferred.
+	KeySelectAll rune = 24
 )
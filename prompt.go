@@ -1,10 +1,16 @@
 package promptui
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"os/exec"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 
 	"github.com/ergochat/readline"
 )
@@ -17,41 +23,410 @@ type Prompt struct {
 	// inside the templates. For example, `{{ .Name }}` will display the name property of a struct.
 	Label interface{}
 
+	// MaxLabelWidth is the maximum number of columns used by the rendered label. Longer labels are truncated
+	// with an ellipsis so that they don't push the input off the screen. Wide characters count as two columns
+	// and the styles of the label are preserved. Zero means no limit.
+	MaxLabelWidth int
+
+	// LabelPosition sets where the label is displayed relative to the input. Defaults to LabelInline.
+	LabelPosition LabelPosition
+
+	// Description is an optional text displayed faintly on the line after the label, explaining what to enter.
+	// The input is then displayed on the line below it, as with LabelAbove. The answered prompt leaves it out.
+	Description string
+
+	// LabelSeparator is the text displayed between the label and the input by the default templates, like "= "
+	// or " → ". Its surrounding spaces are not styled. Defaults to ": ".
+	LabelSeparator string
+
 	// Default is the initial value for the prompt. This value will be displayed next to the prompt's label
 	// and the user will be able to view or change it depending on the options.
 	Default string
 
-	// AllowEdit lets the user edit the default value. If false, any key press
-	// other than <Enter> automatically clears the default value.
+	// DefaultFunc is an optional function computing the default value when Run starts, replacing Default with
+	// its result. It is useful when the default is slow to compute, like a value fetched from a server: a
+	// spinner is displayed after the label while it runs.
+	DefaultFunc func() string
+
+	// AllowEdit lets the user edit the default value. If false, the first key press decides what happens to
+	// the default value: a character replaces it, <Backspace> clears it and the arrow keys keep it so it can
+	// be edited.
 	AllowEdit bool
 
+	// AutoSubmitDefault submits the Default value automatically once this duration has elapsed without a key
+	// press, for values detected by the program that the user only needs to review. The remaining time is
+	// displayed after the input and any key stops the countdown. The default must pass the validation for the
+	// countdown to start. Zero disables it.
+	AutoSubmitDefault time.Duration
+
+	// TimeoutWarning renders the countdown of AutoSubmitDefault with the Warning template once the remaining
+	// time is below it, so the user notices the value is about to be submitted. Zero keeps the faint countdown.
+	TimeoutWarning time.Duration
+
+	// ReadOnly displays the Default value with the Valid template without allowing it to be edited, like a review
+	// of a previous answer. Any key ends the prompt, and Run returns the Default value unless it is interrupted.
+	ReadOnly bool
+
+	// KeepDefaultOnBackspace makes a <Backspace> pressed as the first key leave the default value untouched
+	// instead of clearing it when AllowEdit is false.
+	KeepDefaultOnBackspace bool
+
+	// Transforms are applied in order to the input before it is validated and returned, like strings.TrimSpace
+	// or strings.ToLower. The input is displayed as typed unless EchoTransforms is set.
+	Transforms []func(string) string
+
+	// EchoTransforms displays the input with the Transforms applied while typing, like upper-casing a code. The
+	// cursor stays at the position in the typed input, so transforms changing the length are better left out.
+	EchoTransforms bool
+
 	// Validate is an optional function that fill be used against the entered value in the prompt to validate it.
 	Validate ValidateFunc
 
+	// ValidateData is an optional function used instead of Validate to validate the entered value while
+	// providing data to the templates, which is useful for checklist-style feedback like "3 of 5 requirements
+	// met". See ValidateDataFunc for more info.
+	ValidateData ValidateDataFunc
+
+	// SuggestMany is an optional function used instead of Validate and ValidateData, suggesting fixes when it
+	// rejects the input. When the value submitted with Enter is rejected, the suggestions are listed under the
+	// error: Up and Down pick one and Enter replaces the input with it, validating it again. Any other key
+	// dismisses the list.
+	SuggestMany SuggestManyFunc
+
+	// ValidateIncremental validates the input from the previous result while characters are appended to it.
+	// The input is validated in full with Validate or ValidateData the first time and whenever it changes
+	// otherwise, like after a deletion, so one of them must be set too. RunList always validates its entries in
+	// full.
+	ValidateIncremental IncrementalValidator
+
+	// ValidationTrace records every validation of the input while the prompt runs when it is not nil, for
+	// debugging validators. The results reused while the input doesn't change aren't recorded again.
+	ValidationTrace *[]ValidationRecord
+
+	// SoftRequired rejects an empty input on Enter with a bell and an inline hint after the input instead of
+	// the ValidationError template, keeping the prompt in place. The hint is displayed until a key is typed.
+	SoftRequired bool
+
+	// DoubleConfirm requires Enter to be pressed twice to submit the value, against accidental submissions of
+	// values starting something destructive. The first Enter validates the value and displays a hint asking to
+	// press it again, and any other key cancels the submission.
+	DoubleConfirm bool
+
+	// ErrorFormatter is an optional function turning a validation error into the message given to the
+	// ValidationError template, for a consistent presentation of the errors of every validator. When nil, the
+	// template receives the error itself.
+	ErrorFormatter func(error) string
+
+	// HighlightFunc is an optional function returning the ranges of the input to style, for live feedback on
+	// the characters making it invalid, like the digits exceeding a maximum. It receives the current input and
+	// isn't used for masked inputs.
+	HighlightFunc func(s string) []Range
+
+	// AllowControlChars lets control characters typed or pasted, like NUL, BEL or ESC, be inserted into the
+	// input. By default they are dropped, as they would corrupt the terminal and the value. Editing keys and
+	// whitespace are never affected.
+	AllowControlChars bool
+
+	// FilterInput is an optional function called with the input and each printable rune before it is inserted.
+	// Returning false rejects the rune, keeping the input unchanged, for constraints depending on the whole
+	// input like a maximum number. The input is empty while a default is about to be erased.
+	FilterInput func(current string, candidate rune) bool
+
 	// Mask is an optional rune that sets which character to display instead of the entered characters. This
 	// allows hiding private information like passwords.
 	Mask rune
 
+	// MaskRunes is an optional set of runes used instead of Mask to hide the entered characters. Each
+	// position displays a rune picked from the set, giving a varied look while staying stable between
+	// refreshes. When empty, Mask is used.
+	MaskRunes []rune
+
+	// MaskedDefault defines how the Default of a masked prompt is displayed. Defaults to MaskedDefaultMask.
+	MaskedDefault MaskedDefaultPolicy
+
+	// ConfirmMatch asks for the value a second time once it has been validated, until both entries match.
+	// This is meant for masked prompts, like choosing a new password, and is ignored when the input isn't
+	// masked. Run returns ErrInterrupt or ErrEOF if the user stops the second entry, and ErrMismatch if it
+	// fails otherwise.
+	ConfirmMatch bool
+
 	// LazyValidation sets whether to validate the input only after the user has pressed enter. If false, the
 	// validation will be done once the user presses enter.
 	LazyValidation bool
 
+	// ValidationBadge displays the validation state as a badge after the input, using the ValidBadge and
+	// InvalidBadge templates, instead of changing the label. The badge is not part of the returned value.
+	ValidationBadge bool
+
+	// ValidationChecklist displays the results of the rules combined with Validators as a checklist under the
+	// input, using the Checklist template. It requires ValidateData to be set with Validators.
+	ValidationChecklist bool
+
+	// ValidationWarnings lists the failing rules of SeverityWarning combined with Validators under the input,
+	// using the ValidationWarning template. It requires ValidateData to be set with Validators.
+	ValidationWarnings bool
+
+	// ListDuplicates tells how RunList handles an entry entered more than once. Defaults to DuplicatesAllow.
+	ListDuplicates DuplicatePolicy
+
+	// Requirements are conditions displayed as a checklist between the label and the input, using the Checklist
+	// template, and checked again after every key. They only inform the user unless EnforceRequirements is set.
+	Requirements []Requirement
+
+	// EnforceRequirements rejects the input until it meets all the Requirements, with the label of the first
+	// unmet one as the validation error. The other validations run once they are met.
+	EnforceRequirements bool
+
+	// IsBusy is an optional function called before validating the input while it is being edited. When it returns
+	// true, for example while another component reports that the user is in the middle of an action, the input
+	// isn't validated and the unvalidated template is displayed. It doesn't affect the validation on submit.
+	IsBusy func() bool
+
+	// ValidateAfterTouch delays the display of the invalid state until the user has tried to submit a value
+	// once, so that the prompt doesn't flag an input as invalid while the first characters are being typed.
+	// The unvalidated template is used until then.
+	ValidateAfterTouch bool
+
 	// Templates can be used to customize the prompt output. If nil is passed, the
 	// default templates are used. See the PromptTemplates docs for more info.
 	Templates *PromptTemplates
 
 	// IsConfirm makes the prompt ask for a yes or no ([Y/N]) question rather than request an input. When set,
 	// most properties related to input will be ignored.
+	//
+	// Setting Mask on a confirm prompt hides the answer entirely, for decisions that shouldn't be visible to
+	// someone looking at the screen: neither the typed character nor a mask is displayed.
 	IsConfirm bool
 
-	// IsVimMode enables vi-like movements (hjkl) and editing.
+	// ConfirmNormalize is an optional function applied to the answer of a confirm prompt before it is compared
+	// to "y" and "n", mapping localized answers like "はい" or "１" to the expected ones.
+	ConfirmNormalize func(string) string
+
+	// ConfirmDecider is an optional function replacing the comparison deciding whether the answer of a confirm
+	// prompt accepts it. It receives the Default and the answer, after ConfirmNormalize. When nil, an empty
+	// answer takes the default and only "y" accepts the prompt, ignoring case.
+	ConfirmDecider func(defaultVal, input string) (accepted bool)
+
+	// IsVimMode enables vi-like movements (hjkl) and editing. ESC switches to the normal mode, where keys like
+	// x, dw or r edit the input, and keys like i or A switch back to the insert mode.
 	IsVimMode bool
 
+	// InitialError is an optional error displayed with the ValidationError template when the prompt opens,
+	// before the user has entered anything. It is useful when asking again for a value that was rejected
+	// outside of the prompt, for example by a remote server. The error is cleared by the first key press.
+	InitialError error
+
+	// MaxAttempts limits the number of times RunUntil and RunWithFinalizer ask for the value when it is
+	// rejected after being submitted. Once reached, the value and the last error are returned. Zero means no
+	// limit.
+	MaxAttempts int
+
+	// OnEOF defines what Run returns when the input ends before a value was submitted, for example
+	// when stdin is an empty pipe. Defaults to EOFErrorOut. A confirm prompt whose default refuses it returns
+	// ErrAbort instead of a value.
+	OnEOF EOFPolicy
+
+	// RawMode disables the handling of the Tab and arrow keys by the prompt. Instead, those keys are passed to
+	// OnRawKey, which gives full control over them to programs implementing their own line semantics, like
+	// REPLs. It is unrelated to the raw mode of the terminal.
+	RawMode bool
+
+	// OnRawKey is called with the key and the current input when one of the keys handled by RawMode is pressed.
+	// The returned value replaces the input.
+	OnRawKey func(key rune, input string) string
+
+	// EnvVar is the name of an environment variable providing the value. When it is set to a non-empty value,
+	// Run validates it and returns it without displaying the prompt, so that values can be given either
+	// interactively or through the environment. A value failing the validation is returned as an error, and
+	// a value refusing a confirm prompt returns ErrAbort like the same answer typed.
+	EnvVar string
+
+	// AllowEditorLaunch lets KeyEditor open the input in the editor set by the VISUAL or EDITOR environment
+	// variables, vi by default, for long values. The prompt is suspended until the editor exits, then the
+	// edited text replaces the input and is validated. If the editor fails, the input is left unchanged and the
+	// error is displayed.
+	AllowEditorLaunch bool
+
+	// AllowSelectAll lets KeySelectAll select the whole input, to replace a prefilled value at once: the
+	// selection is highlighted until the next key, a character replacing the input with itself and Backspace
+	// clearing it. The other keys, like the arrows, drop the selection and move the cursor as usual.
+	AllowSelectAll bool
+
+	// AbortKey is the key ending the prompt with ErrAbort, letting programs tell a field skipped by the user apart
+	// from an interrupt with Ctrl-C, which still returns ErrInterrupt. Zero defaults to Esc, except with IsVimMode
+	// where Esc switches to the normal mode. NoAbortKey disables it.
+	AbortKey rune
+
+	// InterruptExitCode exits the program with this code when the prompt is interrupted with <Ctrl-C>, once the
+	// terminal is restored, instead of returning ErrInterrupt. Leave it nil to handle ErrInterrupt. Deferred
+	// functions don't run, as with os.Exit.
+	InterruptExitCode *int
+
+	// ShouldRun is an optional predicate called at the start of Run. When it returns false, the prompt is
+	// skipped without being displayed and Run returns the Default value. This is useful for prompts that
+	// only apply depending on previous answers.
+	ShouldRun func() bool
+
+	// Examples are example inputs displayed as a faint placeholder while the input is empty. The placeholder
+	// rotates through them every couple of seconds until the first key press. They are never part of the value.
+	Examples []string
+
+	// DynamicDefault is an optional function suggesting a completed value for the current input, like shells
+	// suggesting a command from their history. When the suggestion extends the input, the rest of it is displayed
+	// faintly after the cursor and Tab, or the Forward key at the end of the input, accepts it. It is called after
+	// every key and isn't used for masked inputs.
+	DynamicDefault func(current string) string
+
+	// OnChange is an optional function called with the input every time it changes.
+	OnChange func(input string)
+
+	// ChangeDebounce delays OnChange until the input hasn't changed for this duration, for expensive side
+	// effects like live previews. OnChange is then called from another goroutine, and a change pending when the
+	// prompt ends is dropped. Zero calls OnChange on every change.
+	ChangeDebounce time.Duration
+
+	// Words is a vocabulary used to complete the input with Tab. The first Tab replaces the input with the word
+	// starting with it that matches it best, as ranked by FuzzyMatch, and the next ones cycle through the other
+	// words starting with it. Any other key keeps the completed word.
+	Words []string
+
+	// UseSessionSuggestions completes the input with Tab from the values accepted by the previous prompts of
+	// the program, like a host entered earlier, after the Words. See SessionSuggestions.
+	UseSessionSuggestions bool
+
+	// MaxVisibleSuggestions lists the words matching the input under it while completing with Tab, showing at
+	// most this many of them with a "(+N more)" indicator for the others. The arrow keys then move through the
+	// list like Tab, scrolling it. Zero doesn't list the words.
+	MaxVisibleSuggestions int
+
+	// TabBehavior sets what the Tab key does. Defaults to TabComplete.
+	TabBehavior TabBehavior
+
+	// VerticalKeyBehavior sets what the Up and Down keys (KeyPrev and KeyNext) do. Defaults to VerticalIgnore.
+	// While MaxVisibleSuggestions lists completions, they move through the list instead.
+	VerticalKeyBehavior VerticalKeyBehavior
+
+	// Options are suggested values displayed as a faint hint after the input, like "[1] foo [2] bar". While the
+	// input is empty, or still holds an erasable default, pressing the digit of an option fills the input with
+	// it. Any other input can still be typed freely. Only the first nine options can be picked.
+	Options []string
+
+	// TriggerRunes are runes that submit the prompt as soon as they are typed, without being added to the input.
+	// RunResult reports which rune ended the prompt, letting callers branch on it, for example to switch a
+	// command palette to a sub-mode.
+	TriggerRunes map[rune]struct{}
+
+	// TriggerSkipsValidation submits the input without validating it when the prompt is ended by one of the
+	// TriggerRunes.
+	TriggerSkipsValidation bool
+
+	// AcceptKey is called with every key, before the line editor handles it. When it returns true, the prompt
+	// is submitted right away with the key as its value, without validation, building hotkey menus on top of
+	// a prompt. RunResult reports the key as a trigger.
+	AcceptKey func(rune) bool
+
+	// Step locates the prompt in a set of prompts, displayed by the default templates before the label. RunSteps
+	// and FillStruct set it.
+	Step StepInfo
+
+	// NoTrailingNewline omits the line break written after the success line, for programs capturing the output
+	// of the prompt. The next output then starts on the same line as the answered prompt.
+	NoTrailingNewline bool
+
+	// TruncateSuccess shortens the success line to the width of the terminal so that it doesn't wrap, for long
+	// values like paths or tokens. The label is shortened first, keeping the end of the value visible.
+	TruncateSuccess bool
+
+	// ClearOnCancel erases the prompt when it is interrupted with <Ctrl-C> or aborted with the AbortKey,
+	// instead of leaving the abandoned prompt in the scrollback.
+	ClearOnCancel bool
+
+	// Frame draws a box around the prompt while it's running, with an optional title. See the Frame docs for
+	// more info.
+	Frame *Frame
+
 	// the Pointer defines how to render the cursor.
 	Pointer Pointer
 
+	// Renderer draws the prompt. If nil, the prompt is drawn on Stdout with ANSI escape codes. See the Renderer
+	// docs for more info.
+	Renderer Renderer
+
+	// AskPass is the path of a program asking for masked values when there is no terminal to read them from,
+	// like SSH_ASKPASS for headless environments and GUI launchers. It is run with the label as its argument
+	// and the line it prints is the value, which must pass the validation.
+	AskPass string
+
+	// FallbackLineMode reads the value as a plain line when the line editor can't be set up, for example in
+	// constrained environments without a terminal, instead of failing. The label is displayed without live
+	// validation nor editing keys, the input is validated once it is read and masked inputs are echoed.
+	FallbackLineMode bool
+
+	// DemoScript is typed into the prompt before reading Stdin, for recording demos of a program. The keys go
+	// through the same handling as the ones of the user, so the prompt is drawn as if they were typed, and the
+	// input is only read once the script is over. A script ending with KeyEnter submits the prompt by itself.
+	// Leave it nil to read the input right away. It is not replayed by the second entry of ConfirmMatch.
+	DemoScript []DemoKey
+
 	Stdin  io.Reader
 	Stdout io.Writer
+
+	result RunResult
+
+	// aborted reports whether the last run ended with the AbortKey, telling it from a refused confirm prompt.
+	aborted bool
+
+	// clock is the time source of the timer-based features, replaced by the tests.
+	clock clock
+
+	// active is the readline instance of the running prompt and canceled reports whether Cancel stopped it,
+	// both guarded by activeMu. parent is the prompt owning them when running the second entry of ConfirmMatch.
+	active   *readline.Instance
+	canceled bool
+	parent   *Prompt
+
+	// running is the edit state of the prompt while Run is reading its input, guarded by activeMu. restored is
+	// the state set by RestoreState for the next run.
+	running  *editState
+	restored *PromptState
+
+	// finalize is the function given to RunWithFinalizer, run on the submitted value.
+	finalize func(value string) error
+
+	// editing is the state of the prompt while it is driven with Update.
+	editing *editState
+
+	// shared is the Session running the prompt, if any.
+	shared *Session
+
+	// listDelim separates the entries of the input when the prompt is run by RunList.
+	listDelim rune
+}
+
+// NoAbortKey is the AbortKey of a prompt that can't be aborted.
+const NoAbortKey rune = -1
+
+// exit ends the program for InterruptExitCode, replaced by the tests.
+var exit = os.Exit
+
+// forceInteractive makes readline draw the prompt on outputs that aren't terminals, leaving the terminal mode
+// alone, set by the tests.
+var forceInteractive bool
+
+// activeMu guards the state shared by Run and Cancel, which are called from different goroutines.
+var activeMu sync.Mutex
+
+// RunResult describes how the last execution of a prompt ended.
+type RunResult struct {
+	// Value is the value returned by Run.
+	Value string
+
+	// TerminatedBy is the key that submitted the value, either the enter key, one of the TriggerRunes or a key
+	// accepted by AcceptKey.
+	TerminatedBy rune
+
+	// Triggered reports whether the value was submitted by one of the TriggerRunes or AcceptKey.
+	Triggered bool
 }
 
 // PromptTemplates allow a prompt to be customized following stdlib
@@ -94,6 +469,10 @@ type PromptTemplates struct {
 	// inside the console.
 	Success string
 
+	// Unfocused is a text/template for the prompt label rendered dimmed by RenderUnfocused, for the fields of a
+	// form not being edited.
+	Unfocused string
+
 	// Unvalidated is a text/template for the prompt label when the value entered is unvalidated.
 	// this is the state used when the LazyValidation option is set to true.
 	Unvalidated string
@@ -102,6 +481,33 @@ type PromptTemplates struct {
 	// the prompt's validation function.
 	ValidationError string
 
+	// ValidBadge is a text/template for the badge displayed after a valid input when ValidationBadge is set.
+	ValidBadge string
+
+	// InvalidBadge is a text/template for the badge displayed after an invalid input when ValidationBadge
+	// is set.
+	InvalidBadge string
+
+	// Checklist is a text/template for the checklist displayed under the input when ValidationChecklist is set,
+	// and above it for the Requirements. It receives the []RuleResult of the rules combined with Validators, or
+	// the results of the requirements, an unmet one having its label as the error.
+	Checklist string
+
+	// ValidationWarning is a text/template for each warning listed under the input when ValidationWarnings is
+	// set. It receives the RuleResult of the failing rule.
+	ValidationWarning string
+
+	// VimMode is a text/template for the indicator displayed after the input when IsVimMode is set. It receives
+	// the current mode, either "INSERT" or "NORMAL".
+	VimMode string
+
+	// Description is a text/template for the Description of the prompt, displayed under the label.
+	Description string
+
+	// Warning is a text/template for the countdown displayed after the input in the last seconds of
+	// AutoSubmitDefault, when TimeoutWarning is set. It receives the remaining number of seconds.
+	Warning string
+
 	// FuncMap is a map of helper functions that can be used inside of templates according to the text/template
 	// documentation.
 	//
@@ -109,18 +515,395 @@ type PromptTemplates struct {
 	// is overridden, the colors functions must be added in the override from promptui.FuncMap to work.
 	FuncMap template.FuncMap
 
-	prompt      *template.Template
-	valid       *template.Template
-	invalid     *template.Template
-	validation  *template.Template
-	success     *template.Template
-	unvalidated *template.Template
+	prompt       *template.Template
+	valid        *template.Template
+	invalid      *template.Template
+	validation   *template.Template
+	success      *template.Template
+	unfocused    *template.Template
+	unvalidated  *template.Template
+	vimMode      *template.Template
+	validBadge   *template.Template
+	invalidBadge *template.Template
+	checklist    *template.Template
+	ruleWarning  *template.Template
+	warning      *template.Template
+	description  *template.Template
+
+	data map[string]interface{}
+}
+
+// RunResult returns how the last call to Run ended. It is only meaningful after Run returned a value without
+// error.
+func (p *Prompt) RunResult() RunResult {
+	return p.result
+}
+
+// exampleInterval is the delay before the placeholder shows the next of the Examples.
+const exampleInterval = 2 * time.Second
+
+// LabelPosition defines where the label of a prompt is displayed.
+type LabelPosition int
+
+const (
+	// LabelInline displays the label on the same line as the input, before it.
+	LabelInline LabelPosition = iota
+
+	// LabelAbove displays the label on its own line, with the input on the line below.
+	LabelAbove
+)
+
+// TabBehavior defines what the Tab key does in a prompt.
+type TabBehavior int
+
+const (
+	// TabComplete completes the input from the prompt's Words, or inserts a tab when there are none.
+	TabComplete TabBehavior = 0
+
+	// TabIgnore ignores the Tab key.
+	TabIgnore TabBehavior = -1
+
+	// TabLiteral always inserts a tab, even when the prompt has Words.
+	TabLiteral TabBehavior = -2
+)
+
+// TabInsertSpaces returns a TabBehavior inserting n spaces in place of a tab. n is at least 1.
+func TabInsertSpaces(n int) TabBehavior {
+	if n < 1 {
+		n = 1
+	}
+	return TabBehavior(n)
 }
 
+// VerticalKeyBehavior defines what the Up and Down keys do in a prompt.
+type VerticalKeyBehavior int
+
+const (
+	// VerticalIgnore ignores the Up and Down keys.
+	VerticalIgnore VerticalKeyBehavior = iota
+
+	// VerticalStartEnd moves the cursor to the start of the input with Up and to its end with Down.
+	VerticalStartEnd
+
+	// VerticalHistoryNav recalls the values accepted by the previous prompts of the program, listed by
+	// SessionSuggestions, like a shell history: Up replaces the input with the previous value and Down with the
+	// next one, back to the input typed before the first Up. Masked prompts ignore the keys.
+	VerticalHistoryNav
+)
+
+// EOFPolicy defines how a prompt behaves when its input ends before a value was submitted.
+type EOFPolicy int
+
+const (
+	// EOFErrorOut makes Run return ErrEOF.
+	EOFErrorOut EOFPolicy = iota
+
+	// EOFReturnDefault makes Run return the prompt's Default value without an error, or the error of its
+	// validation when the default isn't valid.
+	EOFReturnDefault
+
+	// EOFReturnEmpty makes Run return an empty value without an error.
+	EOFReturnEmpty
+)
+
+// MaskedDefaultPolicy defines how a prompt hiding its input with a mask displays its Default value.
+type MaskedDefaultPolicy int
+
+const (
+	// MaskedDefaultMask hides the default behind the mask like typed input, whether it is erased by the first
+	// key or edited with AllowEdit.
+	MaskedDefaultMask MaskedDefaultPolicy = iota
+
+	// MaskedDefaultReveal displays the default in clear text until the first key is pressed, masking the input
+	// from then on, for placeholder-like defaults that aren't secret.
+	MaskedDefaultReveal
+
+	// MaskedDefaultOmit doesn't prefill the input with the default, which is never displayed. Submitting an
+	// empty input submits the default instead.
+	MaskedDefaultOmit
+)
+
 // Run executes the prompt. Its displays the label and default value if any, asking the user to enter a value.
 // Run will keep the prompt alive until it has been canceled from the command prompt or it has received a valid
 // value. It will return the value and an error if any occurred during the prompt's execution.
 func (p *Prompt) Run() (string, error) {
+	if p.ShouldRun != nil && !p.ShouldRun() {
+		if p.DefaultFunc != nil {
+			return p.DefaultFunc(), nil
+		}
+		return p.Default, nil
+	}
+
+	if p.EnvVar != "" {
+		if value := os.Getenv(p.EnvVar); value != "" {
+			value = p.transform(value)
+			if err := p.validateValue(value); err != nil {
+				return "", fmt.Errorf("%s: %w", p.EnvVar, err)
+			}
+			if p.IsConfirm && !p.confirmAccepted(value) {
+				return "", ErrAbort
+			}
+			return value, nil
+		}
+	}
+
+	if p.AskPass != "" && len(p.masks()) != 0 && !isTerminal(p.Stdin) {
+		return p.askPass()
+	}
+
+	value, err := p.run()
+	if err == nil && p.ConfirmMatch && len(p.masks()) != 0 {
+		value, err = p.confirmMatch(value)
+		if err == nil && p.finalize != nil {
+			stdout := p.Stdout
+			if stdout == nil {
+				stdout = os.Stdout
+			}
+			if err := p.finalizeValue(stdout, value); err != nil {
+				return value, finalizeError{err}
+			}
+		}
+	}
+	if err == ErrInterrupt && p.InterruptExitCode != nil {
+		// the Session keeps the terminal set up between its prompts.
+		if p.shared != nil {
+			p.shared.Close()
+		}
+		exit(*p.InterruptExitCode)
+	}
+	return value, err
+}
+
+// RunWithFinalizer runs the prompt like Run, then calls finalize with the submitted value for work completing
+// the answer, like saving it. A spinner is displayed after the answered prompt while finalize runs. If finalize
+// returns an error, the prompt runs again with the value as an editable default and the error displayed, until
+// finalize succeeds, the prompt fails or MaxAttempts is reached.
+func (p *Prompt) RunWithFinalizer(finalize func(value string) error) (string, error) {
+	def, defaultFunc, allowEdit, initialErr := p.Default, p.DefaultFunc, p.AllowEdit, p.InitialError
+	defer func() {
+		p.Default, p.DefaultFunc, p.AllowEdit, p.InitialError = def, defaultFunc, allowEdit, initialErr
+		p.finalize = nil
+	}()
+
+	p.finalize = finalize
+	for attempt := 1; ; attempt++ {
+		value, err := p.Run()
+
+		var failed finalizeError
+		if !errors.As(err, &failed) {
+			return value, err
+		}
+		if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+			return value, failed.err
+		}
+		// the value replaces the default, which DefaultFunc would compute again.
+		p.Default, p.DefaultFunc, p.AllowEdit, p.InitialError = value, nil, true, failed.err
+	}
+}
+
+// RunUntil runs the prompt like Run, then calls accept with the submitted value for checks done by the caller,
+// like a request to a server. If accept returns an error, the prompt runs again with the value as an editable
+// default and the error displayed, until accept returns nil, the prompt fails or MaxAttempts is reached. Unlike
+// RunWithFinalizer, accept is called once the answered prompt is displayed.
+func (p *Prompt) RunUntil(accept func(value string) error) (string, error) {
+	def, defaultFunc, allowEdit, initialErr := p.Default, p.DefaultFunc, p.AllowEdit, p.InitialError
+	defer func() {
+		p.Default, p.DefaultFunc, p.AllowEdit, p.InitialError = def, defaultFunc, allowEdit, initialErr
+	}()
+
+	for attempt := 1; ; attempt++ {
+		value, err := p.Run()
+		if err != nil {
+			return value, err
+		}
+
+		err = accept(value)
+		if err == nil || (p.MaxAttempts > 0 && attempt >= p.MaxAttempts) {
+			return value, err
+		}
+		// the value replaces the default, which DefaultFunc would compute again.
+		p.Default, p.DefaultFunc, p.AllowEdit, p.InitialError = value, nil, true, err
+	}
+}
+
+// finalizeError is the error of the finalizer of RunWithFinalizer, making it prompt again.
+type finalizeError struct {
+	err error
+}
+
+func (e finalizeError) Error() string {
+	return e.err.Error()
+}
+
+// transform applies the Transforms to value.
+func (p *Prompt) transform(value string) string {
+	for _, fn := range p.Transforms {
+		value = fn(value)
+	}
+	return value
+}
+
+// validateValue validates a value obtained without running the prompt, like the one read from EnvVar.
+func (p *Prompt) validateValue(value string) error {
+	if err := p.unmetRequirement(value); err != nil {
+		return err
+	}
+
+	if p.listDelim != 0 {
+		entry := *p
+		entry.listDelim, entry.EnforceRequirements = 0, false
+		return p.validateList(entry.validateValue)(value)
+	}
+
+	switch {
+	case p.SuggestMany != nil:
+		_, err := p.SuggestMany(value)
+		return err
+	case p.ValidateData != nil:
+		_, err := p.ValidateData(value)
+		return err
+	case p.Validate != nil:
+		return p.Validate(value)
+	}
+	return nil
+}
+
+// askPass runs the AskPass program with the label as its argument and returns the line it printed, validated.
+func (p *Prompt) askPass() (string, error) {
+	out, err := exec.Command(p.AskPass, fmt.Sprint(p.Label)).Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.AskPass, err)
+	}
+
+	value := p.transform(strings.TrimRight(string(out), "\r\n"))
+	if err := p.validateValue(value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// inputEnded reports whether r, a nil reader being the standard input, is known to be at its end without
+// reading it: an empty in-memory reader, a regular file read to its end or the null device. Other readers, like
+// pipes, can't be checked without blocking until they are written to, which Cancel couldn't interrupt.
+func inputEnded(r io.Reader) bool {
+	if r == nil {
+		r = os.Stdin
+	}
+
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return r.Len() == 0
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil {
+			return false
+		}
+		if info.Mode().IsRegular() {
+			offset, err := r.Seek(0, io.SeekCurrent)
+			return err == nil && offset >= info.Size()
+		}
+		null, err := os.Stat(os.DevNull)
+		return err == nil && os.SameFile(info, null)
+	}
+	return false
+}
+
+// isTerminal reports whether r is a terminal. A nil reader is the standard input.
+func isTerminal(r io.Reader) bool {
+	if r == nil {
+		r = os.Stdin
+	}
+
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// RunConfirm runs the prompt as a confirm prompt, as if IsConfirm was set, and reports whether the user accepted it.
+// Refusing the prompt returns false without an error, unlike Run which returns ErrAbort. Other errors, like
+// ErrInterrupt or the ErrAbort of the AbortKey, are returned as is.
+func (p *Prompt) RunConfirm() (bool, error) {
+	result, err := p.RunConfirmResult()
+	return result.Accepted, err
+}
+
+// ConfirmResult describes the answer of a confirm prompt run with RunConfirmResult.
+type ConfirmResult struct {
+	// Accepted reports whether the user accepted the prompt.
+	Accepted bool
+
+	// Explicit reports whether the user typed an answer, rather than taking the default with Enter.
+	Explicit bool
+
+	// Raw is the answer as typed, before ConfirmNormalize. It is empty when the default was taken.
+	Raw string
+
+	// Key is the key that submitted the answer, like the enter key or one of the TriggerRunes. It is zero
+	// when the prompt didn't read its answer, for example when ShouldRun skipped it.
+	Key rune
+}
+
+// RunConfirmResult runs the prompt as a confirm prompt like RunConfirm, also reporting how it was answered, to
+// tell an accepted default from an explicit yes.
+func (p *Prompt) RunConfirmResult() (ConfirmResult, error) {
+	isConfirm := p.IsConfirm
+	defer func() { p.IsConfirm = isConfirm }()
+
+	p.IsConfirm = true
+	p.result, p.aborted = RunResult{}, false
+
+	value, err := p.Run()
+	result := ConfirmResult{
+		Accepted: err == nil,
+		Explicit: p.result.Value != "",
+		Raw:      p.result.Value,
+		Key:      p.result.TerminatedBy,
+	}
+	// without reading an answer, like when ShouldRun skipped it, Run returns the default as is.
+	if err == nil && result.Key == 0 {
+		result.Accepted = p.confirmAccepted(value)
+	}
+
+	if err == ErrAbort && !p.aborted {
+		err = nil
+	}
+	return result, err
+}
+
+// confirmMatch asks for value a second time until the user enters it identically.
+func (p *Prompt) confirmMatch(value string) (string, error) {
+	confirm := *p
+	confirm.Label = "Confirm"
+	confirm.Default = ""
+	confirm.DefaultFunc = nil
+	confirm.Validate = nil
+	confirm.ValidateData = nil
+	confirm.SuggestMany = nil
+	confirm.ValidateIncremental = nil
+	confirm.InitialError = nil
+	confirm.OnEOF = EOFErrorOut
+	confirm.parent = p
+
+	for {
+		again, err := confirm.run()
+		if err == ErrCanceled || err == ErrInterrupt || err == ErrEOF {
+			return "", err
+		}
+		if err != nil {
+			return "", ErrMismatch
+		}
+		if again == value {
+			return value, nil
+		}
+		confirm.InitialError = ErrMismatch
+	}
+}
+
+func (p *Prompt) run() (string, error) {
 	var err error
 
 	err = p.prepareTemplates()
@@ -128,84 +911,177 @@ func (p *Prompt) Run() (string, error) {
 		return "", err
 	}
 
+	shared := p.shared
+
+	stdin := p.Stdin
+	switch {
+	case shared != nil:
+		stdin = shared.Stdin
+		// the line editor of the Session may hold keys already read from the input.
+		if shared.rl == nil && inputEnded(stdin) {
+			return p.endOfInput()
+		}
+	case len(p.DemoScript) != 0 && p.parent == nil:
+		stdin = newDemoReader(p.timeSource(), p.DemoScript, stdin)
+	case inputEnded(stdin):
+		// drawing the prompt would only leave escape codes in the output.
+		return p.endOfInput()
+	}
+
+	masks := p.masks()
+
+	var out *frameWriter
+	if shared != nil {
+		out = shared.writer()
+	} else {
+		stdout := p.Stdout
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		out = &frameWriter{w: stdout}
+	}
+	if err := out.failure(); err != nil {
+		return "", outputError(err)
+	}
+
+	in := stdin
+	if in == nil {
+		in = os.Stdin
+	}
+	esc := p.IsVimMode || p.abortKey() == readline.CharEsc
+	if shared != nil {
+		in = shared.reader(in, esc, p.IsVimMode)
+	} else if esc {
+		in = newEscapeReader(in, p.IsVimMode)
+	}
+
 	c := &readline.Config{
-		Stdin:        p.Stdin,
-		Stdout:       p.Stdout,
-		EnableMask:   p.Mask != 0,
+		Stdin:        frameReader{r: in, out: out},
+		Stdout:       out,
+		EnableMask:   len(masks) != 0,
 		MaskRune:     p.Mask,
 		HistoryLimit: -1,
-		VimMode:      p.IsVimMode,
+	}
+	if forceInteractive {
+		c.ForceUseInteractive = true
+		c.FuncMakeRaw = func() error { return nil }
+		c.FuncExitRaw = func() error { return nil }
 	}
 
-	rl, err := readline.NewFromConfig(c)
+	var rl *readline.Instance
+	if shared != nil {
+		rl, err = shared.instance(c)
+	} else {
+		rl, err = readline.NewFromConfig(c)
+	}
 	if err != nil {
+		if p.FallbackLineMode {
+			return p.readLine(stdin, out)
+		}
 		return "", err
 	}
-	// we're taking over the cursor, so stop showing it.
-	rl.Write([]byte(hideCursor))
+	// rendering into a dead output, like a closed pager, would go on until the input ends.
+	out.failed = func(error) { go rl.Close() }
 
-	validFn := func(x string) error {
-		return nil
+	renderer := p.Renderer
+	if renderer == nil {
+		renderer = &ansiRenderer{rl: rl, out: out, erase: p.Frame != nil}
 	}
-	if p.Validate != nil {
-		validFn = p.Validate
+
+	// we're taking over the cursor, so stop showing it until the prompt is done, however it ends. In a Session,
+	// the default renderer leaves it hidden for the next prompt until the Session is closed.
+	renderer.HideCursor()
+	defer func() {
+		if shared == nil || p.Renderer != nil || shared.rl == nil {
+			renderer.ShowCursor()
+		}
+		if shared == nil {
+			rl.Close()
+		}
+	}()
+
+	owner := p
+	if p.parent != nil {
+		owner = p.parent
 	}
+	owner.setActive(rl)
+	defer owner.setActive(nil)
 
-	input := p.Default
-	if p.IsConfirm {
-		input = ""
+	if p.DefaultFunc != nil {
+		p.Default = p.loadDefault(rl)
 	}
-	eraseDefault := input != "" && !p.AllowEdit
-	cur := NewCursor(input, p.Pointer, eraseDefault)
 
-	listen := func(input []rune, pos int, key rune) ([]rune, int, bool) {
-		_, _, keepOn := cur.Listen(input, pos, key)
-		var prompt []byte
+	s := p.newEditState(renderer)
+	p.setRunning(s)
+	defer p.setRunning(nil)
 
-		if !p.LazyValidation {
-			err := validFn(cur.Get())
+	s.width = func() int {
+		width, _ := c.FuncGetSize()
+		return width
+	}
 
-			if err != nil {
-				prompt = render(p.Templates.invalid, p.Label)
-			} else {
-				prompt = render(p.Templates.valid, p.Label)
-				if p.IsConfirm {
-					prompt = render(p.Templates.prompt, p.Label)
-				}
-			}
-		} else {
-			prompt = render(p.Templates.unvalidated, p.Label)
-		}
+	c.Listener = s.listen
+	// everything drawn for a key, by the prompt and by readline, is written at once. The frame of the previous
+	// key is still held when readline had the next one buffered.
+	c.FuncFilterInputRune = func(r rune) (rune, bool) {
+		out.flush()
+		out.hold()
+		return s.filter(r)
+	}
 
-		echo := cur.Format()
-		if p.Mask != 0 {
-			echo = cur.FormatMask(p.Mask)
-		}
+	// readline only reads the next key once the filter returns, so the editor can use the terminal meanwhile.
+	s.suspend = func(fn func()) {
+		out.flush()
+		c.FuncExitRaw()
+		renderer.ShowCursor()
+		defer func() {
+			renderer.HideCursor()
+			c.FuncMakeRaw()
+			out.hold()
+		}()
+		fn()
+	}
 
-		prompt = append(prompt, []byte(echo)...)
-		rl.SetPrompt(string(prompt))
-		rl.Refresh()
-		return nil, 0, keepOn
+	stop := make(chan struct{})
+	if len(p.Examples) > 1 {
+		go s.rotateExamples(stop)
 	}
 
-	c.Listener = listen
-	c.FuncFilterInputRune = func(r rune) (rune, bool) {
-		switch r {
-		case readline.CharEnter, readline.CharCtrlJ:
-			err = validFn(cur.Get())
-			if err != nil {
-				validation := render(p.Templates.validation, err)
-				rl.SetPrompt(string(validation))
-				return r, false
-			}
-			return r, true
-		}
-		return r, true
+	if p.AutoSubmitDefault > 0 && p.Default != "" && s.validFn(p.Default) == nil {
+		s.autoSubmitAt = s.clock.Now().Add(p.AutoSubmitDefault)
+		go s.autoSubmit(func() { rl.Close() }, stop)
 	}
 
 	_, err = rl.ReadLine()
+	out.flush()
+
+	s.stop()
+	close(stop)
+
+	if shared != nil && (s.autoSubmitted || owner.wasCanceled() || out.failure() != nil) {
+		shared.discard()
+	}
+
+	if err := out.failure(); err != nil {
+		return "", outputError(err)
+	}
+
+	if err != nil && s.autoSubmitted {
+		err = nil
+	}
 
 	if err != nil {
+		if owner.wasCanceled() {
+			return "", ErrCanceled
+		}
+		if c, ok := renderer.(clearRenderer); ok && p.ClearOnCancel &&
+			(s.aborted || err == readline.ErrInterrupt || err.Error() == "Interrupt") {
+			c.Clear()
+		}
+		if s.aborted {
+			p.aborted = true
+			return "", ErrAbort
+		}
 		switch err {
 		case readline.ErrInterrupt:
 			err = ErrInterrupt
@@ -215,33 +1091,261 @@ func (p *Prompt) Run() (string, error) {
 		if err.Error() == "Interrupt" {
 			err = ErrInterrupt
 		}
+		if err == ErrEOF {
+			// the default was computed already.
+			loaded := *p
+			loaded.DefaultFunc = nil
+			return loaded.endOfInput()
+		}
 		return "", err
 	}
 
-	echo := cur.Get()
-	if p.Mask != 0 {
-		echo = cur.GetMask(p.Mask)
+	// with ConfirmMatch, the value is only final once both entries matched, so Run finalizes it.
+	value := s.value()
+	if p.finalize != nil && p.parent == nil && (!p.ConfirmMatch || len(masks) == 0) &&
+		(!p.IsConfirm || p.confirmAccepted(value)) {
+		if err := p.finalizeValue(rl, value); err != nil {
+			return value, finalizeError{err}
+		}
 	}
 
-	prompt := render(p.Templates.success, p.Label)
-	prompt = append(prompt, []byte(echo)...)
+	line, err := s.finish()
+	renderer.DrawSuccess(line)
 
-	if p.IsConfirm {
-		lowerDefault := strings.ToLower(p.Default)
-		inputLower := strings.ToLower(cur.Get())
-		if (lowerDefault == "y" && inputLower == "n") || (lowerDefault != "y" && inputLower != "y") {
-			prompt = render(p.Templates.invalid, p.Label)
-			err = ErrAbort
+	if werr := out.failure(); werr != nil && err == nil {
+		err = outputError(werr)
+	}
+	return value, err
+}
+
+// outputError wraps the error the output of a prompt failed with.
+func outputError(err error) error {
+	return fmt.Errorf("promptui: writing the prompt: %w", err)
+}
+
+// readLine reads the value as a plain line of the input, printing the label once and validating the value once.
+// An empty line takes the default.
+func (p *Prompt) readLine(stdin io.Reader, w io.Writer) (string, error) {
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+
+	w.Write(p.renderLabel(p.Templates.prompt))
+
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err == io.EOF && line == "" {
+		return p.endOfInput()
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	value := strings.TrimRight(line, "\r\n")
+	if value == "" && !p.IsConfirm {
+		value = p.Default
+	}
+
+	s := p.newEditState(&frameRenderer{})
+	s.cur.Replace(value)
+	value = s.value()
+	if err := s.validFn(value); err != nil {
+		return "", err
+	}
+
+	success, err := s.finish()
+	w.Write([]byte(success))
+	return value, err
+}
+
+// endOfInput returns what Run returns according to OnEOF when the input ends before a value was submitted.
+func (p *Prompt) endOfInput() (string, error) {
+	switch p.OnEOF {
+	case EOFReturnDefault, EOFReturnEmpty:
+	default:
+		return "", ErrEOF
+	}
+
+	// the input may end before run computed the default, which the answer depends on.
+	if p.DefaultFunc != nil {
+		p.Default = p.DefaultFunc()
+	}
+
+	var value string
+	if p.OnEOF == EOFReturnDefault {
+		value = p.Default
+		if err := p.validateValue(value); err != nil {
+			return "", err
+		}
+	}
+
+	// an empty answer takes the default of a confirm prompt, which may refuse it.
+	if p.IsConfirm && !p.confirmAccepted(value) {
+		return "", ErrAbort
+	}
+	return value, nil
+}
+
+// confirmAccepted reports whether answer accepts a confirm prompt, given its default.
+func (p *Prompt) confirmAccepted(answer string) bool {
+	if p.ConfirmNormalize != nil {
+		answer = p.ConfirmNormalize(answer)
+	}
+	if p.ConfirmDecider != nil {
+		return p.ConfirmDecider(p.Default, answer)
+	}
+
+	answer = strings.ToLower(answer)
+	if strings.ToLower(p.Default) == "y" {
+		return answer != "n"
+	}
+	return answer == "y"
+}
+
+// Cancel stops the prompt currently running in another goroutine, tearing down its input and making Run
+// return ErrCanceled. It does nothing if the prompt isn't running.
+func (p *Prompt) Cancel() {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+
+	if p.active != nil {
+		p.canceled = true
+		p.active.Close()
+	}
+}
+
+// setActive records the readline instance of the running prompt, or nil once it has ended.
+func (p *Prompt) setActive(rl *readline.Instance) {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+
+	p.active = rl
+	if rl != nil {
+		p.canceled = false
+	}
+}
+
+// setRunning records the edit state of the running prompt, or nil once it has ended.
+func (p *Prompt) setRunning(s *editState) {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+
+	p.running = s
+}
+
+func (p *Prompt) wasCanceled() bool {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+
+	return p.canceled
+}
+
+// optionsHint renders the hint listing the options that can be picked with a digit.
+func optionsHint(options []string) string {
+	if len(options) > 9 {
+		options = options[:9]
+	}
+
+	hint := make([]string, len(options))
+	for i, o := range options {
+		hint[i] = fmt.Sprintf("[%d] %s", i+1, o)
+	}
+	return " " + Styler(FGFaint)(strings.Join(hint, " "))
+}
+
+// isRawKey reports whether r is one of the keys passed to OnRawKey in RawMode.
+func isRawKey(r rune) bool {
+	switch r {
+	case KeyTab, KeyPrev, KeyNext, KeyForward, KeyBackward:
+		return true
+	}
+	return false
+}
+
+// RenderSuccess returns the line displayed by Run once value has been accepted, without running the prompt.
+// It can be used to display a value obtained elsewhere with the same style as an answered prompt.
+func (p *Prompt) RenderSuccess(value string) (string, error) {
+	err := p.prepareTemplates()
+	if err != nil {
+		return "", err
+	}
+
+	return string(p.successLine(value, 0)), nil
+}
+
+// RenderUnfocused returns the prompt dimmed, with the Unfocused template followed by value, without running it.
+// Programs displaying several prompts as a form can use it to draw the fields not being edited, value being the
+// current value of the field, and draw them again with RenderSuccess or Run when they get the focus.
+func (p *Prompt) RenderUnfocused(value string) (string, error) {
+	err := p.prepareTemplates()
+	if err != nil {
+		return "", err
+	}
+
+	line := string(p.renderLabel(p.Templates.unfocused))
+	if p.LabelPosition == LabelAbove {
+		line += "\n"
+	}
+	if value = p.displayedValue(value); value != "" {
+		line += Styler(FGFaint)(value)
+	}
+	return line, nil
+}
+
+// successLine renders the success template followed by value, masked if the prompt hides its input. When width
+// is positive and TruncateSuccess is set, the line is shortened to fit in width columns.
+func (p *Prompt) successLine(value string, width int) []byte {
+	value = p.displayedValue(value)
+
+	prompt := p.renderLabel(p.Templates.success)
+
+	if p.TruncateSuccess && width > 0 {
+		switch vw := DisplayWidth(value); {
+		case p.LabelPosition == LabelAbove:
+			prompt = []byte(truncateWidth(string(prompt), width))
+			value = truncateStartWidth(value, width)
+		case vw >= width:
+			prompt = []byte(truncateWidth(string(prompt), 0))
+			value = truncateStartWidth(value, width)
+		default:
+			prompt = []byte(truncateWidth(string(prompt), width-vw))
 		}
+	}
 
+	if p.LabelPosition == LabelAbove {
+		prompt = append(prompt, '\n')
 	}
+	return append(prompt, []byte(value)...)
+}
 
-	rl.Write(prompt)
-	rl.Write([]byte("\n"))
-	rl.Write([]byte(showCursor))
-	rl.Close()
+// displayedValue returns value as displayed once entered, masked if the prompt hides its input.
+func (p *Prompt) displayedValue(value string) string {
+	switch masks := p.masks(); {
+	case len(masks) != 0 && p.IsConfirm:
+		return ""
+	case len(masks) != 0:
+		return string(maskRunes(len([]rune(value)), masks))
+	}
+	return value
+}
+
+// renderLabel renders the label with the given template, truncated to MaxLabelWidth.
+func (p *Prompt) renderLabel(tpl *template.Template) []byte {
+	label := render(tpl, p.Label)
+	if p.MaxLabelWidth > 0 {
+		label = []byte(truncateWidth(string(label), p.MaxLabelWidth))
+	}
+	return label
+}
 
-	return cur.Get(), err
+// masks returns the runes used to hide the input, or nil when the input is displayed as is.
+func (p *Prompt) masks() []rune {
+	if len(p.MaskRunes) != 0 {
+		return p.MaskRunes
+	}
+	if p.Mask != 0 {
+		return []rune{p.Mask}
+	}
+	return nil
 }
 
 func (p *Prompt) prepareTemplates() error {
@@ -254,18 +1358,40 @@ func (p *Prompt) prepareTemplates() error {
 		tpls.FuncMap = FuncMap
 	}
 
+	// the data function gives templates access to what ValidateData returned for the current input.
+	funcs := template.FuncMap{
+		"data": func(key string) interface{} {
+			return tpls.data[key]
+		},
+		"step": func() StepInfo {
+			return p.Step
+		},
+	}
+	for name, fn := range tpls.FuncMap {
+		funcs[name] = fn
+	}
+
 	bold := Styler(FGBold)
 
+	// the default templates start with the position of the prompt when it is one of a set.
+	const step = `{{ with step }}{{ if .Total }}{{ printf "[%d/%d]" .Index .Total | faint }} {{ end }}{{ end }}`
+
+	sep := p.LabelSeparator
+	if sep == "" {
+		sep = ": "
+	}
+
 	if p.IsConfirm {
 		if tpls.Confirm == "" {
 			confirm := "y/N"
 			if strings.ToLower(p.Default) == "y" {
 				confirm = "Y/n"
 			}
-			tpls.Confirm = fmt.Sprintf(`{{ "%s" | bold }} {{ . | bold }}? {{ "[%s]" | faint }} `, IconInitial, confirm)
+			tpls.Confirm = step +
+				fmt.Sprintf(`{{ "%s" | bold }} {{ . | bold }}? {{ "[%s]" | faint }} `, IconInitial, confirm)
 		}
 
-		tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(tpls.Confirm)
+		tpl, err := template.New("").Funcs(funcs).Parse(tpls.Confirm)
 		if err != nil {
 			return err
 		}
@@ -273,10 +1399,10 @@ func (p *Prompt) prepareTemplates() error {
 		tpls.prompt = tpl
 	} else {
 		if tpls.Prompt == "" {
-			tpls.Prompt = fmt.Sprintf("%s {{ . | bold }}%s ", bold(IconInitial), bold(":"))
+			tpls.Prompt = step + fmt.Sprintf("%s {{ . | bold }}%s", bold(IconInitial), styleSeparator(sep, bold))
 		}
 
-		tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(tpls.Prompt)
+		tpl, err := template.New("").Funcs(funcs).Parse(tpls.Prompt)
 		if err != nil {
 			return err
 		}
@@ -285,10 +1411,10 @@ func (p *Prompt) prepareTemplates() error {
 	}
 
 	if tpls.Valid == "" {
-		tpls.Valid = fmt.Sprintf("%s {{ . | bold }}%s ", bold(IconGood), bold(":"))
+		tpls.Valid = step + fmt.Sprintf("%s {{ . | bold }}%s", bold(iconGood()), styleSeparator(sep, bold))
 	}
 
-	tpl, err := template.New("").Funcs(tpls.FuncMap).Parse(tpls.Valid)
+	tpl, err := template.New("").Funcs(funcs).Parse(tpls.Valid)
 	if err != nil {
 		return err
 	}
@@ -296,10 +1422,10 @@ func (p *Prompt) prepareTemplates() error {
 	tpls.valid = tpl
 
 	if tpls.Unvalidated == "" {
-		tpls.Unvalidated = fmt.Sprintf("%s {{ . | bold }}%s ", bold(IconInitial), bold(":"))
+		tpls.Unvalidated = step + fmt.Sprintf("%s {{ . | bold }}%s", bold(IconInitial), styleSeparator(sep, bold))
 	}
 
-	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Unvalidated)
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Unvalidated)
 	if err != nil {
 		return err
 	}
@@ -307,10 +1433,10 @@ func (p *Prompt) prepareTemplates() error {
 	tpls.unvalidated = tpl
 
 	if tpls.Invalid == "" {
-		tpls.Invalid = fmt.Sprintf("%s {{ . | bold }}%s ", bold(IconBad), bold(":"))
+		tpls.Invalid = step + fmt.Sprintf("%s {{ . | bold }}%s", bold(iconBad()), styleSeparator(sep, bold))
 	}
 
-	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Invalid)
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Invalid)
 	if err != nil {
 		return err
 	}
@@ -321,7 +1447,7 @@ func (p *Prompt) prepareTemplates() error {
 		tpls.ValidationError = `{{ ">>" | red }} {{ . | red }} {{ "Press any key to get back to the prompt" | faint }}`
 	}
 
-	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.ValidationError)
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.ValidationError)
 	if err != nil {
 		return err
 	}
@@ -329,17 +1455,118 @@ func (p *Prompt) prepareTemplates() error {
 	tpls.validation = tpl
 
 	if tpls.Success == "" {
-		tpls.Success = fmt.Sprintf("{{ . | faint }}%s ", Styler(FGFaint)(":"))
+		tpls.Success = step + fmt.Sprintf("{{ . | faint }}%s", styleSeparator(sep, Styler(FGFaint)))
 	}
 
-	tpl, err = template.New("").Funcs(tpls.FuncMap).Parse(tpls.Success)
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Success)
 	if err != nil {
 		return err
 	}
 
 	tpls.success = tpl
 
+	if tpls.Unfocused == "" {
+		tpls.Unfocused = step + fmt.Sprintf("{{ . | faint }}%s", styleSeparator(sep, Styler(FGFaint)))
+	}
+
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Unfocused)
+	if err != nil {
+		return err
+	}
+
+	tpls.unfocused = tpl
+
+	if tpls.ValidBadge == "" {
+		tpls.ValidBadge = ` {{ "[valid]" | green }}`
+	}
+
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.ValidBadge)
+	if err != nil {
+		return err
+	}
+
+	tpls.validBadge = tpl
+
+	if tpls.InvalidBadge == "" {
+		tpls.InvalidBadge = ` {{ "[invalid]" | red }}`
+	}
+
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.InvalidBadge)
+	if err != nil {
+		return err
+	}
+
+	tpls.invalidBadge = tpl
+
+	if tpls.Checklist == "" {
+		tpls.Checklist = fmt.Sprintf(`{{ range . }}{{ "\n  " }}`+
+			`{{ if not .Err }}%s {{ .Name | faint }}`+
+			`{{ else if .Current }}%s {{ .Name }}`+
+			`{{ else }}{{ "-" | faint }} {{ .Name | faint }}{{ end }}{{ end }}`, iconGood(), iconBad())
+	}
+
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Checklist)
+	if err != nil {
+		return err
+	}
+
+	tpls.checklist = tpl
+
+	if tpls.ValidationWarning == "" {
+		tpls.ValidationWarning = `{{ "\n  " }}{{ "!" | warning }} {{ .Err | warning }}`
+	}
+
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.ValidationWarning)
+	if err != nil {
+		return err
+	}
+
+	tpls.ruleWarning = tpl
+
+	if tpls.VimMode == "" {
+		tpls.VimMode = ` {{ printf "-- %s --" . | faint }}`
+	}
+
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.VimMode)
+	if err != nil {
+		return err
+	}
+
+	tpls.vimMode = tpl
+
+	if tpls.Warning == "" {
+		tpls.Warning = ` {{ printf "auto-continuing in %d" . | warning }}`
+	}
+
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Warning)
+	if err != nil {
+		return err
+	}
+
+	tpls.warning = tpl
+
+	if tpls.Description == "" {
+		tpls.Description = `  {{ . | faint }}`
+	}
+
+	tpl, err = template.New("").Funcs(funcs).Parse(tpls.Description)
+	if err != nil {
+		return err
+	}
+
+	tpls.description = tpl
+
 	p.Templates = tpls
 
 	return nil
 }
+
+// styleSeparator applies style to the label separator sep, leaving its surrounding spaces unstyled.
+func styleSeparator(sep string, style func(interface{}) string) string {
+	trimmed := strings.Trim(sep, " ")
+	if trimmed == "" {
+		return sep
+	}
+	i := strings.Index(sep, trimmed)
+	return sep[:i] + style(trimmed) + sep[i+len(trimmed):]
+}
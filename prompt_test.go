@@ -0,0 +1,1239 @@
+package promptui
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPromptRun(t *testing.T) {
+	t.Run("restores the cursor after an interrupt", func(t *testing.T) {
+		var out bytes.Buffer
+		p := Prompt{
+			Label:  "Name",
+			Stdin:  strings.NewReader("ab\x03"),
+			Stdout: &out,
+		}
+
+		_, err := p.Run()
+		if err != ErrInterrupt {
+			t.Fatalf("expected ErrInterrupt, got %v", err)
+		}
+
+		if !strings.HasSuffix(out.String(), showCursor) {
+			t.Errorf("expected output to end with %q, got %q", showCursor, out.String())
+		}
+	})
+
+	t.Run("restores the cursor after a submit", func(t *testing.T) {
+		var out bytes.Buffer
+		p := Prompt{
+			Label:  "Name",
+			Stdin:  strings.NewReader("ab\n"),
+			Stdout: &out,
+		}
+
+		value, err := p.Run()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if value != "ab" {
+			t.Errorf("expected value ab, got %q", value)
+		}
+
+		if !strings.HasSuffix(out.String(), "ab\n"+showCursor) {
+			t.Errorf("expected output to end with the value and %q, got %q", showCursor, out.String())
+		}
+	})
+
+	t.Run("omits the trailing newline", func(t *testing.T) {
+		var out bytes.Buffer
+		p := Prompt{
+			Label:             "Name",
+			NoTrailingNewline: true,
+			Stdin:             strings.NewReader("ab\n"),
+			Stdout:            &out,
+		}
+
+		_, err := p.Run()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if !strings.HasSuffix(out.String(), "ab"+showCursor) {
+			t.Errorf("expected output to end with the value and %q, got %q", showCursor, out.String())
+		}
+	})
+}
+
+type recordingRenderer struct {
+	calls  []string
+	prompt string
+}
+
+func (r *recordingRenderer) DrawPrompt(prompt string) {
+	r.calls = append(r.calls, "prompt")
+	r.prompt = prompt
+}
+
+func (r *recordingRenderer) DrawError(message string) { r.calls = append(r.calls, "error") }
+func (r *recordingRenderer) DrawSuccess(line string)  { r.calls = append(r.calls, "success:"+line) }
+func (r *recordingRenderer) HideCursor()              { r.calls = append(r.calls, "hide") }
+func (r *recordingRenderer) ShowCursor()              { r.calls = append(r.calls, "show") }
+
+func TestPromptRenderer(t *testing.T) {
+	var out bytes.Buffer
+	r := &recordingRenderer{}
+	p := Prompt{
+		Label: "Name",
+		Validate: func(s string) error {
+			if s == "" {
+				return ErrAbort
+			}
+			return nil
+		},
+		Templates: &PromptTemplates{Success: "{{ . }}: "},
+		Renderer:  r,
+		Stdin:     strings.NewReader("\nab\n"),
+		Stdout:    &out,
+	}
+
+	_, err := p.Run()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	calls := strings.Join(r.calls, ",")
+	expected := "hide,prompt,error,prompt,prompt,success:Name: ab\n,show"
+	if calls != expected {
+		t.Errorf("expected calls %q, got %q", expected, calls)
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("expected nothing written to Stdout, got %q", out.String())
+	}
+}
+
+func TestPromptDefaultFunc(t *testing.T) {
+	defer func(icons IconSet) { Icons = icons }(Icons)
+	Icons = IconsASCII
+
+	var out bytes.Buffer
+	c := newFakeClock()
+	release := make(chan struct{})
+	p := Prompt{
+		Label: "Name",
+		DefaultFunc: func() string {
+			<-release
+			return "slow"
+		},
+		Stdin:  strings.NewReader("\n"),
+		Stdout: &out,
+		clock:  c,
+	}
+
+	type result struct {
+		value string
+		err   error
+	}
+	done := make(chan result)
+	go func() {
+		value, err := p.Run()
+		done <- result{value, err}
+	}()
+
+	// the spinner schedules its next frame once it has drawn one.
+	c.wait(t, 1)
+	c.Advance(spinnerInterval)
+	c.wait(t, 1)
+	close(release)
+
+	r := <-done
+	value, err := r.value, r.err
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if value != "slow" {
+		t.Errorf("expected value slow, got %q", value)
+	}
+
+	if !strings.Contains(out.String(), Styler(FGFaint)(asciiSpinner[0])) {
+		t.Errorf("expected the spinner to be displayed, got %q", out.String())
+	}
+}
+
+func TestPromptVimMode(t *testing.T) {
+	for _, c := range []struct {
+		input string
+		mode  string
+	}{
+		{"ab", "INSERT"},
+		{"ab\x1b", "NORMAL"},
+		{"ab\x1bhi", "INSERT"},
+		{"ab\x1b[D", "INSERT"},
+	} {
+		r := &recordingRenderer{}
+		p := Prompt{Label: "Name", IsVimMode: true, Renderer: r, Stdin: strings.NewReader(c.input),
+			Stdout: &bytes.Buffer{}}
+
+		p.Run()
+		if !strings.HasSuffix(r.prompt, Styler(FGFaint)("-- "+c.mode+" --")) {
+			t.Errorf("expected the %s mode for %q, got %q", c.mode, c.input, r.prompt)
+		}
+	}
+
+	for _, c := range []struct {
+		input    string
+		expected string
+	}{
+		{"ab\x1bhhx\r", "b"},
+		{"foo bar\x1b0dwx\r", "ar"},
+		{"abc\x1b0ix\x1bA!\r", "xabc!"},
+		{"a b c\x1bbbrX\r", "a X c"},
+	} {
+		p := Prompt{Label: "Name", IsVimMode: true, Stdin: strings.NewReader(c.input), Stdout: &bytes.Buffer{}}
+
+		value, err := p.Run()
+		if err != nil || value != c.expected {
+			t.Errorf("expected %q for %q, got %q, %v", c.expected, c.input, value, err)
+		}
+	}
+}
+
+func TestPromptValidateData(t *testing.T) {
+	var out bytes.Buffer
+	p := Prompt{
+		Label: "Password",
+		ValidateData: func(s string) (map[string]interface{}, error) {
+			met := len(s)
+			if met > 3 {
+				met = 3
+			}
+			data := map[string]interface{}{"met": met}
+			if met < 3 {
+				return data, ErrAbort
+			}
+			return data, nil
+		},
+		Templates: &PromptTemplates{
+			Valid:   `{{ . }} ok: `,
+			Invalid: `{{ . }} {{ data "met" }}/3: `,
+			Success: `{{ . }} {{ data "met" }}/3: `,
+		},
+		Stdin:  strings.NewReader("abc\n"),
+		Stdout: &out,
+	}
+
+	_, err := p.Run()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Password 3/3: abc\n") {
+		t.Errorf("expected the success line to use the validation data, got %q", out.String())
+	}
+}
+
+func TestPromptCancel(t *testing.T) {
+	// the input is silent: Cancel must not wait for a key.
+	stdin, w := io.Pipe()
+	defer w.Close()
+
+	file, fw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	defer fw.Close()
+
+	inputs := map[string]io.Reader{"in-memory pipe": stdin, "os pipe": file}
+	for name, stdin := range inputs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			p := &Prompt{
+				Label:  "Name",
+				Stdin:  stdin,
+				Stdout: &out,
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := p.Run()
+				done <- err
+			}()
+
+			deadline := time.After(time.Second)
+			for {
+				activeMu.Lock()
+				running := p.active != nil
+				activeMu.Unlock()
+				if running {
+					break
+				}
+				select {
+				case <-deadline:
+					t.Fatal("expected the prompt to start without input")
+				case <-time.After(time.Millisecond):
+				}
+			}
+
+			p.Cancel()
+
+			select {
+			case err := <-done:
+				if err != ErrCanceled {
+					t.Errorf("expected ErrCanceled, got %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("expected Run to return after Cancel")
+			}
+		})
+	}
+}
+
+func TestPromptLabelSeparator(t *testing.T) {
+	p := Prompt{Label: "Name", LabelSeparator: " = "}
+
+	line, err := p.RenderSuccess("ab")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := Styler(FGFaint)("Name") + " " + Styler(FGFaint)("=") + " ab"
+	if line != expected {
+		t.Errorf("expected %q, got %q", expected, line)
+	}
+}
+
+func TestPromptConfirmNormalize(t *testing.T) {
+	normalize := func(s string) string {
+		if s == "はい" {
+			return "y"
+		}
+		return s
+	}
+
+	for _, c := range []struct {
+		input    string
+		expected error
+	}{
+		{"はい\n", nil},
+		{"y\n", nil},
+		{"いいえ\n", ErrAbort},
+	} {
+		p := Prompt{
+			Label:            "Continue",
+			IsConfirm:        true,
+			ConfirmNormalize: normalize,
+			Stdin:            strings.NewReader(c.input),
+			Stdout:           &bytes.Buffer{},
+		}
+
+		_, err := p.Run()
+		if err != c.expected {
+			t.Errorf("expected %v for %q, got %v", c.expected, c.input, err)
+		}
+	}
+}
+
+func TestPromptConfirmDecider(t *testing.T) {
+	// the destructive default is only accepted when typed in full.
+	decide := func(defaultVal, input string) bool {
+		return input == defaultVal
+	}
+
+	for _, c := range []struct {
+		input    string
+		expected bool
+	}{
+		{"DELETE\n", true},
+		{"y\n", false},
+		{"\n", false},
+	} {
+		p := Prompt{
+			Label:          "Delete",
+			Default:        "DELETE",
+			ConfirmDecider: decide,
+			Stdin:          strings.NewReader(c.input),
+			Stdout:         &bytes.Buffer{},
+		}
+
+		accepted, err := p.RunConfirm()
+		if err != nil || accepted != c.expected {
+			t.Errorf("expected %v for %q, got %v, %v", c.expected, c.input, accepted, err)
+		}
+	}
+}
+
+func TestPromptRunConfirm(t *testing.T) {
+	for _, c := range []struct {
+		input    string
+		def      string
+		expected bool
+		err      error
+	}{
+		{"y\n", "", true, nil},
+		{"n\n", "", false, nil},
+		{"\n", "", false, nil},
+		{"\n", "y", true, nil},
+		{"\x03", "y", false, ErrInterrupt},
+	} {
+		p := Prompt{
+			Label:   "Continue",
+			Default: c.def,
+			Stdin:   strings.NewReader(c.input),
+			Stdout:  &bytes.Buffer{},
+		}
+
+		ok, err := p.RunConfirm()
+		if ok != c.expected || err != c.err {
+			t.Errorf("expected %v, %v for %q, got %v, %v", c.expected, c.err, c.input, ok, err)
+		}
+	}
+}
+
+func TestPromptRunConfirmResult(t *testing.T) {
+	for _, c := range []struct {
+		input    string
+		expected ConfirmResult
+	}{
+		{"\r", ConfirmResult{Accepted: true, Key: KeyEnter}},
+		{"y\r", ConfirmResult{Accepted: true, Explicit: true, Raw: "y", Key: KeyEnter}},
+		{"N\r", ConfirmResult{Explicit: true, Raw: "N", Key: KeyEnter}},
+	} {
+		p := Prompt{
+			Label:   "Continue",
+			Default: "y",
+			Stdin:   strings.NewReader(c.input),
+			Stdout:  &bytes.Buffer{},
+		}
+
+		result, err := p.RunConfirmResult()
+		if result != c.expected || err != nil {
+			t.Errorf("expected %+v for %q, got %+v, %v", c.expected, c.input, result, err)
+		}
+	}
+}
+
+func TestPromptRunConfirmAbortKey(t *testing.T) {
+	for _, c := range []struct {
+		input    string
+		expected error
+	}{
+		{"n\r", nil},
+		{"n\x1b", ErrAbort},
+	} {
+		p := Prompt{Label: "Continue", Stdin: strings.NewReader(c.input), Stdout: &bytes.Buffer{}}
+
+		ok, err := p.RunConfirm()
+		if ok || err != c.expected {
+			t.Errorf("expected %v for %q, got %v, %v", c.expected, c.input, ok, err)
+		}
+	}
+}
+
+func TestPromptRunConfirmRestoresPrompt(t *testing.T) {
+	p := Prompt{Label: "Name", Stdin: strings.NewReader("y\n"), Stdout: &bytes.Buffer{}}
+	if ok, err := p.RunConfirm(); !ok || err != nil {
+		t.Fatalf("expected the prompt to be accepted, got %v, %v", ok, err)
+	}
+
+	p.Stdin = strings.NewReader("n\n")
+	if value, err := p.Run(); p.IsConfirm || err != nil || value != "n" {
+		t.Errorf("expected Run to prompt for a value after RunConfirm, got %q, %v", value, err)
+	}
+}
+
+func TestPromptRunConfirmResultSkipped(t *testing.T) {
+	for _, def := range []string{"y", "N"} {
+		p := Prompt{Label: "Continue", Default: def, ShouldRun: func() bool { return false }}
+
+		result, err := p.RunConfirmResult()
+		if expected := (ConfirmResult{Accepted: def == "y"}); result != expected || err != nil {
+			t.Errorf("expected %+v for the default %q, got %+v, %v", expected, def, result, err)
+		}
+	}
+}
+
+func TestPromptIsBusy(t *testing.T) {
+	for _, busy := range []bool{false, true} {
+		r := &recordingRenderer{}
+		p := Prompt{
+			Label:     "Name",
+			Validate:  func(s string) error { return ErrAbort },
+			IsBusy:    func() bool { return busy },
+			Templates: &PromptTemplates{Invalid: "invalid ", Unvalidated: "unvalidated "},
+			Renderer:  r,
+			Stdin:     strings.NewReader("a"),
+			Stdout:    &bytes.Buffer{},
+		}
+
+		p.Run()
+
+		expected := "invalid "
+		if busy {
+			expected = "unvalidated "
+		}
+		if !strings.HasPrefix(r.prompt, expected) {
+			t.Errorf("expected the %q template while busy is %v, got %q", expected, busy, r.prompt)
+		}
+	}
+}
+
+func TestPromptExamples(t *testing.T) {
+	placeholder := Styler(FGFaint)("john")
+
+	for _, c := range []struct {
+		input    string
+		expected bool
+	}{
+		{"", true},
+		{"a", false},
+	} {
+		r := &recordingRenderer{}
+		p := Prompt{
+			Label:    "Name",
+			Examples: []string{"john", "jane"},
+			Renderer: r,
+			// hides the length of the input, which would end the prompt before drawing it when empty.
+			Stdin:  io.MultiReader(strings.NewReader(c.input)),
+			Stdout: &bytes.Buffer{},
+		}
+
+		p.Run()
+
+		if strings.Contains(r.prompt, placeholder) != c.expected {
+			t.Errorf("expected placeholder %v after %q, got %q", c.expected, c.input, r.prompt)
+		}
+	}
+}
+
+func TestPromptReadOnly(t *testing.T) {
+	r := &recordingRenderer{}
+	p := Prompt{
+		Label:     "Name",
+		Default:   "john",
+		ReadOnly:  true,
+		Templates: &PromptTemplates{Valid: "{{ . }}: "},
+		Renderer:  r,
+		Stdin:     strings.NewReader("x"),
+		Stdout:    &bytes.Buffer{},
+	}
+
+	value, err := p.Run()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if value != "john" {
+		t.Errorf("expected the default to be returned unchanged, got %q", value)
+	}
+	if r.prompt != "Name: john" {
+		t.Errorf("expected the value to be displayed without a cursor, got %q", r.prompt)
+	}
+}
+
+func TestPromptEnvVar(t *testing.T) {
+	validate := func(s string) error {
+		if s == "bad" {
+			return ErrAbort
+		}
+		return nil
+	}
+
+	t.Run("uses the environment without prompting", func(t *testing.T) {
+		t.Setenv("PROMPTUI_TEST_NAME", "john")
+
+		var out bytes.Buffer
+		p := Prompt{Label: "Name", EnvVar: "PROMPTUI_TEST_NAME", Validate: validate, Stdout: &out}
+
+		value, err := p.Run()
+		if err != nil || value != "john" {
+			t.Errorf("expected john, got %q, %v", value, err)
+		}
+		if out.Len() != 0 {
+			t.Errorf("expected nothing to be displayed, got %q", out.String())
+		}
+	})
+
+	t.Run("validates the environment", func(t *testing.T) {
+		t.Setenv("PROMPTUI_TEST_NAME", "bad")
+
+		p := Prompt{Label: "Name", EnvVar: "PROMPTUI_TEST_NAME", Validate: validate}
+
+		_, err := p.Run()
+		if !errors.Is(err, ErrAbort) {
+			t.Errorf("expected the validation error, got %v", err)
+		}
+	})
+
+	t.Run("decides confirm prompts", func(t *testing.T) {
+		t.Setenv("PROMPTUI_TEST_DELETE", "n")
+
+		p := Prompt{Label: "Delete", IsConfirm: true, Default: "y", EnvVar: "PROMPTUI_TEST_DELETE"}
+		if ok, err := p.RunConfirm(); ok || err != nil {
+			t.Errorf("expected the environment to refuse the prompt, got %v, %v", ok, err)
+		}
+		if _, err := p.Run(); err != ErrAbort {
+			t.Errorf("expected ErrAbort, got %v", err)
+		}
+
+		t.Setenv("PROMPTUI_TEST_DELETE", "y")
+		if ok, err := p.RunConfirm(); !ok || err != nil {
+			t.Errorf("expected the environment to accept the prompt, got %v, %v", ok, err)
+		}
+	})
+
+	t.Run("prompts when the environment is empty", func(t *testing.T) {
+		t.Setenv("PROMPTUI_TEST_NAME", "")
+
+		p := Prompt{
+			Label:  "Name",
+			EnvVar: "PROMPTUI_TEST_NAME",
+			Stdin:  strings.NewReader("jane\n"),
+			Stdout: &bytes.Buffer{},
+		}
+
+		value, err := p.Run()
+		if err != nil || value != "jane" {
+			t.Errorf("expected jane, got %q, %v", value, err)
+		}
+	})
+}
+
+func TestPromptAbortKey(t *testing.T) {
+	for _, c := range []struct {
+		key      rune
+		input    string
+		expected error
+		value    string
+	}{
+		{'\a', "ab\a", ErrAbort, ""},
+		{'\a', "ab\x03", ErrInterrupt, ""},
+		{0, "ab\x1b", ErrAbort, ""},
+		{0, "ab\x1b[D\x1b[Dx\r", nil, "xab"},
+		{0, "ab\x1bbx\r", nil, "abx"},
+		{NoAbortKey, "ab\x1b", ErrEOF, ""},
+	} {
+		p := Prompt{
+			Label:    "Name",
+			AbortKey: c.key,
+			Stdin:    strings.NewReader(c.input),
+			Stdout:   &bytes.Buffer{},
+		}
+
+		value, err := p.Run()
+		if err != c.expected || value != c.value {
+			t.Errorf("expected %q, %v for %q, got %q, %v", c.value, c.expected, c.input, value, err)
+		}
+	}
+}
+
+func TestPromptAcceptKey(t *testing.T) {
+	p := Prompt{
+		Label:     "Action",
+		AcceptKey: func(r rune) bool { return r >= '1' && r <= '9' },
+		Validate:  func(s string) error { return ErrAbort },
+		Stdin:     strings.NewReader("ab2"),
+		Stdout:    &bytes.Buffer{},
+	}
+
+	value, err := p.Run()
+	if err != nil || value != "2" {
+		t.Fatalf("expected the accepted key to be the value, got %q, %v", value, err)
+	}
+	if result := p.RunResult(); result.TerminatedBy != '2' || !result.Triggered {
+		t.Errorf("expected the key to be reported as a trigger, got %+v", result)
+	}
+}
+
+func TestPromptMaskedConfirm(t *testing.T) {
+	r := &recordingRenderer{}
+	p := Prompt{
+		Label:     "Delete",
+		IsConfirm: true,
+		Mask:      '*',
+		Pointer:   PipeCursor,
+		Templates: &PromptTemplates{Confirm: "{{ . }}? ", Success: "{{ . }}: "},
+		Renderer:  r,
+		Stdin:     strings.NewReader("y\n"),
+		Stdout:    &bytes.Buffer{},
+	}
+
+	_, err := p.Run()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if r.prompt != "Delete? |" {
+		t.Errorf("expected the answer to be hidden, got %q", r.prompt)
+	}
+	if success := r.calls[len(r.calls)-2]; success != "success:Delete: \n" {
+		t.Errorf("expected the answer to be hidden from the success line, got %q", success)
+	}
+}
+
+func TestPromptLabelAbove(t *testing.T) {
+	r := &recordingRenderer{}
+	p := Prompt{
+		Label:         "Name",
+		LabelPosition: LabelAbove,
+		Pointer:       PipeCursor,
+		Templates:     &PromptTemplates{Valid: "{{ . }}:", Success: "{{ . }}:"},
+		Renderer:      r,
+		Stdin:         strings.NewReader("ab\n"),
+		Stdout:        &bytes.Buffer{},
+	}
+
+	_, err := p.Run()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if r.prompt != "Name:\nab|" {
+		t.Errorf("expected the input below the label, got %q", r.prompt)
+	}
+	if success := r.calls[len(r.calls)-2]; success != "success:Name:\nab\n" {
+		t.Errorf("expected the value below the label, got %q", success)
+	}
+}
+
+func TestPromptDescription(t *testing.T) {
+	r := &recordingRenderer{}
+	p := Prompt{
+		Label:       "Name",
+		Description: "As displayed on your profile",
+		Pointer:     PipeCursor,
+		Templates:   &PromptTemplates{Valid: "{{ . }}", Description: "({{ . }})", Success: "{{ . }}: "},
+		Renderer:    r,
+		Stdin:       strings.NewReader("ab\n"),
+		Stdout:      &bytes.Buffer{},
+	}
+
+	_, err := p.Run()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if r.prompt != "Name\n(As displayed on your profile)\nab|" {
+		t.Errorf("expected the description between the label and the input, got %q", r.prompt)
+	}
+	if success := r.calls[len(r.calls)-2]; success != "success:Name: ab\n" {
+		t.Errorf("expected the success line without the description, got %q", success)
+	}
+}
+
+func TestPromptAutoSubmitDefault(t *testing.T) {
+	t.Run("submits the default", func(t *testing.T) {
+		stdin, w := io.Pipe()
+		defer w.Close()
+
+		p := Prompt{
+			Label:             "Region",
+			Default:           "eu-west-1",
+			AutoSubmitDefault: 20 * time.Millisecond,
+			Stdin:             stdin,
+			Stdout:            &bytes.Buffer{},
+		}
+
+		value, err := p.Run()
+		if err != nil || value != "eu-west-1" {
+			t.Errorf("expected the default to be submitted, got %q, %v", value, err)
+		}
+	})
+
+	t.Run("warns before submitting", func(t *testing.T) {
+		stdin, w := io.Pipe()
+		defer w.Close()
+
+		r := &recordingRenderer{}
+		p := Prompt{
+			Label:             "Region",
+			Default:           "eu-west-1",
+			AutoSubmitDefault: 20 * time.Millisecond,
+			TimeoutWarning:    time.Second,
+			Templates:         &PromptTemplates{Warning: " in {{ . }}"},
+			Renderer:          r,
+			Stdin:             stdin,
+			Stdout:            &bytes.Buffer{},
+		}
+
+		if _, err := p.Run(); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if !strings.HasSuffix(r.prompt, " in 1") {
+			t.Errorf("expected the warning countdown, got %q", r.prompt)
+		}
+	})
+
+	t.Run("stops on a key press", func(t *testing.T) {
+		stdin, w := io.Pipe()
+		defer w.Close()
+
+		p := Prompt{
+			Label:             "Region",
+			Default:           "eu-west-1",
+			AllowEdit:         true,
+			AutoSubmitDefault: 50 * time.Millisecond,
+			Stdin:             stdin,
+			Stdout:            &bytes.Buffer{},
+		}
+
+		go func() {
+			io.WriteString(w, "x")
+			time.Sleep(100 * time.Millisecond)
+			io.WriteString(w, "\r")
+		}()
+
+		value, err := p.Run()
+		if err != nil || value != "eu-west-1x" {
+			t.Errorf("expected the edited value, got %q, %v", value, err)
+		}
+	})
+}
+
+func TestPromptReadLine(t *testing.T) {
+	validate := func(s string) error {
+		if s == "bad" {
+			return ErrAbort
+		}
+		return nil
+	}
+
+	for _, c := range []struct {
+		input    string
+		expected string
+		err      error
+	}{
+		{"john\r\n", "john", nil},
+		{"\n", "anonymous", nil},
+		{"bad\n", "", ErrAbort},
+		{"", "", ErrEOF},
+	} {
+		var out bytes.Buffer
+		p := Prompt{Label: "Name", Default: "anonymous", Validate: validate}
+		if err := p.prepareTemplates(); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		value, err := p.readLine(strings.NewReader(c.input), &out)
+		if value != c.expected || err != c.err {
+			t.Errorf("expected %q, %v for %q, got %q, %v", c.expected, c.err, c.input, value, err)
+		}
+		if !strings.Contains(out.String(), "Name") {
+			t.Errorf("expected the label to be displayed, got %q", out.String())
+		}
+	}
+}
+
+func TestPromptRunWithFinalizer(t *testing.T) {
+	stdin, w := io.Pipe()
+	defer w.Close()
+
+	failed := make(chan struct{})
+	var finalized []string
+	p := Prompt{
+		Label:  "Name",
+		Stdin:  stdin,
+		Stdout: &bytes.Buffer{},
+	}
+
+	go func() {
+		io.WriteString(w, "ab\n")
+		<-failed
+		io.WriteString(w, "c\n")
+	}()
+
+	value, err := p.RunWithFinalizer(func(value string) error {
+		finalized = append(finalized, value)
+		if value == "ab" {
+			close(failed)
+			return errors.New("already taken")
+		}
+		return nil
+	})
+
+	if err != nil || value != "abc" {
+		t.Fatalf("expected the value fixed after the finalizer error, got %q, %v", value, err)
+	}
+	if strings.Join(finalized, ",") != "ab,abc" {
+		t.Errorf("expected both values to be finalized, got %v", finalized)
+	}
+	if p.Default != "" || p.AllowEdit || p.InitialError != nil {
+		t.Errorf("expected the prompt to be restored, got %q, %v, %v", p.Default, p.AllowEdit, p.InitialError)
+	}
+}
+
+func TestPromptTruncateSuccess(t *testing.T) {
+	p := Prompt{
+		Label:           "Config path",
+		TruncateSuccess: true,
+		Templates:       &PromptTemplates{Success: "{{ . }}: "},
+	}
+	if err := p.prepareTemplates(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, c := range []struct {
+		value    string
+		expected string
+	}{
+		{"/etc/app.yml", "Config path: /etc/app.yml"},
+		{"/home/user/app.yml", "Config…/home/user/app.yml"},
+		{"/home/user/projects/app/config.yml", "…/projects/app/config.yml"},
+	} {
+		if line := string(p.successLine(c.value, 25)); line != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, line)
+		}
+	}
+}
+
+func TestPromptAskPass(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the askpass program is a shell script")
+	}
+
+	askpass := filepath.Join(t.TempDir(), "askpass")
+	err := os.WriteFile(askpass, []byte("#!/bin/sh\necho \"$1-secret\"\n"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validate := func(s string) error {
+		if len(s) < 10 {
+			return ErrAbort
+		}
+		return nil
+	}
+
+	for _, c := range []struct {
+		label    string
+		expected string
+		err      error
+	}{
+		{"token", "token-secret", nil},
+		{"a", "", ErrAbort},
+	} {
+		p := Prompt{
+			Label:    c.label,
+			Mask:     '*',
+			AskPass:  askpass,
+			Validate: validate,
+			Stdin:    strings.NewReader(""),
+			Stdout:   &bytes.Buffer{},
+		}
+
+		value, err := p.Run()
+		if value != c.expected || err != c.err {
+			t.Errorf("expected %q, %v for %s, got %q, %v", c.expected, c.err, c.label, value, err)
+		}
+	}
+}
+
+func TestPromptTransforms(t *testing.T) {
+	var validated string
+	p := Prompt{
+		Label:      "Code",
+		Transforms: []func(string) string{strings.TrimSpace, strings.ToUpper},
+		Validate: func(input string) error {
+			validated = input
+			return nil
+		},
+		Stdin:  strings.NewReader("  ab1 \n"),
+		Stdout: &bytes.Buffer{},
+	}
+
+	value, err := p.Run()
+	if err != nil || value != "AB1" {
+		t.Fatalf("expected the transformed value, got %q, %v", value, err)
+	}
+	if validated != "AB1" {
+		t.Errorf("expected the transformed value to be validated, got %q", validated)
+	}
+
+	t.Run("echo", func(t *testing.T) {
+		p := Prompt{Label: "Code", Transforms: []func(string) string{strings.ToUpper}, Pointer: PipeCursor}
+		rendered, _, _, _ := p.Update('a')
+		if !strings.HasSuffix(rendered, " a|") {
+			t.Errorf("expected the input as typed, got %q", rendered)
+		}
+
+		p = Prompt{Label: "Code", Transforms: p.Transforms, EchoTransforms: true, Pointer: PipeCursor}
+		rendered, _, _, _ = p.Update('a')
+		if !strings.HasSuffix(rendered, " A|") {
+			t.Errorf("expected the transformed input, got %q", rendered)
+		}
+	})
+}
+
+func TestPromptRunUntil(t *testing.T) {
+	taken := errors.New("already taken")
+
+	t.Run("until accepted", func(t *testing.T) {
+		stdin, w := io.Pipe()
+		defer w.Close()
+
+		rejected := make(chan struct{})
+		p := Prompt{Label: "Name", Stdin: stdin, Stdout: &bytes.Buffer{}}
+
+		go func() {
+			io.WriteString(w, "ab\n")
+			<-rejected
+			io.WriteString(w, "c\n")
+		}()
+
+		var accepted []string
+		value, err := p.RunUntil(func(value string) error {
+			accepted = append(accepted, value)
+			if value == "ab" {
+				close(rejected)
+				return taken
+			}
+			return nil
+		})
+
+		if err != nil || value != "abc" {
+			t.Fatalf("expected the value fixed after the rejection, got %q, %v", value, err)
+		}
+		if strings.Join(accepted, ",") != "ab,abc" {
+			t.Errorf("expected both values to be checked, got %v", accepted)
+		}
+		if p.Default != "" || p.AllowEdit || p.InitialError != nil {
+			t.Errorf("expected the prompt to be restored, got %q, %v, %v", p.Default, p.AllowEdit, p.InitialError)
+		}
+	})
+
+	t.Run("with DefaultFunc", func(t *testing.T) {
+		stdin, w := io.Pipe()
+		defer w.Close()
+
+		rejected := make(chan struct{})
+		computed := func() string { return "computed" }
+		p := Prompt{Label: "Name", DefaultFunc: computed, AllowEdit: true, Stdin: stdin, Stdout: &bytes.Buffer{}}
+
+		go func() {
+			io.WriteString(w, "X\n")
+			<-rejected
+			io.WriteString(w, "X\n")
+		}()
+
+		value, err := p.RunUntil(func(value string) error {
+			if value == "computedX" {
+				close(rejected)
+				return taken
+			}
+			return nil
+		})
+
+		if err != nil || value != "computedXX" {
+			t.Fatalf("expected the rejected value to prefill the retry, got %q, %v", value, err)
+		}
+		if p.DefaultFunc == nil {
+			t.Errorf("expected DefaultFunc to be restored")
+		}
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		stdin, w := io.Pipe()
+		defer w.Close()
+
+		attempts := make(chan struct{})
+		p := Prompt{Label: "Name", MaxAttempts: 2, Stdin: stdin, Stdout: &bytes.Buffer{}}
+
+		go func() {
+			for _, input := range []string{"a\n", "b\n"} {
+				io.WriteString(w, input)
+				<-attempts
+			}
+		}()
+
+		value, err := p.RunUntil(func(value string) error {
+			attempts <- struct{}{}
+			return taken
+		})
+
+		if err != taken || value != "ab" {
+			t.Errorf("expected the last value and error after 2 attempts, got %q, %v", value, err)
+		}
+	})
+}
+
+func TestPromptRenderUnfocused(t *testing.T) {
+	p := Prompt{Label: "Name"}
+
+	line, err := p.RenderUnfocused("ab")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := Styler(FGFaint)("Name") + Styler(FGFaint)(":") + " " + Styler(FGFaint)("ab")
+	if line != expected {
+		t.Errorf("expected %q, got %q", expected, line)
+	}
+
+	p = Prompt{Label: "Password", Mask: '*', Templates: &PromptTemplates{Unfocused: "{{ . }}: "}}
+	if line, _ := p.RenderUnfocused("ab"); line != "Password: "+Styler(FGFaint)("**") {
+		t.Errorf("expected the masked value, got %q", line)
+	}
+}
+
+func TestPromptEmptyInput(t *testing.T) {
+	var out bytes.Buffer
+	p := Prompt{Label: "Name", Stdin: strings.NewReader(""), Stdout: &out}
+
+	if _, err := p.Run(); err != ErrEOF {
+		t.Errorf("expected ErrEOF, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected nothing to be written, got %q", out.String())
+	}
+
+	p = Prompt{Label: "Name", Default: "anonymous", OnEOF: EOFReturnDefault, Stdin: strings.NewReader(""), Stdout: &out}
+	if value, err := p.Run(); err != nil || value != "anonymous" {
+		t.Errorf("expected the default, got %q, %v", value, err)
+	}
+
+	for _, input := range []string{"", "john\n"} {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.WriteString(input)
+		w.Close()
+
+		out.Reset()
+		p = Prompt{Label: "Name", OnEOF: EOFReturnDefault, Default: "anonymous", Stdin: r, Stdout: &out}
+		value, err := p.Run()
+		r.Close()
+
+		switch {
+		case input == "" && (err != nil || value != "anonymous"):
+			t.Errorf("expected the default for an empty pipe, got %q, %v", value, err)
+		case input != "" && (err != nil || value != "john"):
+			t.Errorf("expected the pipe to be read in full, got %q, %v", value, err)
+		}
+	}
+
+	calls := 0
+	computed := func() string {
+		calls++
+		return "computed"
+	}
+	p = Prompt{Label: "Name", DefaultFunc: computed, OnEOF: EOFReturnDefault, Stdin: strings.NewReader(""),
+		Stdout: &out}
+	if value, err := p.Run(); err != nil || value != "computed" {
+		t.Errorf("expected the default computed by DefaultFunc, got %q, %v", value, err)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	calls = 0
+	p = Prompt{Label: "Name", DefaultFunc: computed, OnEOF: EOFReturnDefault, Stdin: r, Stdout: &out}
+	if value, err := p.Run(); err != nil || value != "computed" || calls != 1 {
+		t.Errorf("expected the default computed once at the end of a pipe, got %q, %v and %d calls", value, err, calls)
+	}
+	r.Close()
+
+	invalid := errors.New("invalid")
+	p = Prompt{Label: "Name", Default: "x", OnEOF: EOFReturnDefault, Stdin: strings.NewReader(""), Stdout: &out,
+		Validate: func(string) error { return invalid }}
+	if _, err := p.Run(); err != invalid {
+		t.Errorf("expected the default to be validated, got %v", err)
+	}
+
+	p = Prompt{Label: "Delete", IsConfirm: true, Default: "N", OnEOF: EOFReturnDefault, Stdin: strings.NewReader(""),
+		Stdout: &out}
+	if ok, err := p.RunConfirm(); ok || err != nil {
+		t.Errorf("expected the default to refuse the confirm prompt, got %v, %v", ok, err)
+	}
+	p.Stdin = strings.NewReader("")
+	if _, err := p.Run(); err != ErrAbort {
+		t.Errorf("expected ErrAbort, got %v", err)
+	}
+
+	p = Prompt{Label: "Name", Stdin: strings.NewReader("john\n"), Stdout: &out}
+	if value, err := p.Run(); err != nil || value != "john" {
+		t.Errorf("expected the input to be read, got %q, %v", value, err)
+	}
+}
+
+// failingWriter is an output that was closed, like the pipe to an exited pager.
+type failingWriter struct{}
+
+var errClosedOutput = errors.New("closed output")
+
+func (failingWriter) Write(b []byte) (int, error) {
+	return 0, errClosedOutput
+}
+
+func TestPromptOutputError(t *testing.T) {
+	stdin, keys := io.Pipe()
+	defer keys.Close()
+
+	p := Prompt{Label: "Name", Stdin: stdin, Stdout: failingWriter{}}
+
+	done := make(chan error)
+	go func() {
+		_, err := p.Run()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, errClosedOutput) {
+			t.Errorf("expected the output error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Run to stop once the output failed")
+	}
+}
+
+func TestPromptInterruptExitCode(t *testing.T) {
+	defer func(e func(int)) { exit = e }(exit)
+
+	var (
+		out    bytes.Buffer
+		code   = -1
+		output string
+	)
+	exit = func(c int) {
+		code, output = c, out.String()
+	}
+
+	two := 2
+	p := Prompt{Label: "Name", InterruptExitCode: &two, Stdin: strings.NewReader("ab\x03"), Stdout: &out}
+	p.Run()
+	if code != 2 {
+		t.Fatalf("expected the program to exit with 2, got %d", code)
+	}
+	if !strings.HasSuffix(output, showCursor) {
+		t.Errorf("expected the cursor to be restored before exiting, got %q", output)
+	}
+
+	code = -1
+	p = Prompt{Label: "Name", InterruptExitCode: &two, Stdin: strings.NewReader("ab\n"), Stdout: &out}
+	if value, err := p.Run(); err != nil || value != "ab" || code != -1 {
+		t.Errorf("expected a submitted prompt to return, got %q, %v and exit code %d", value, err, code)
+	}
+
+	// a Session keeps the keys the first entry read ahead for the confirmation.
+	code = -1
+	s := Session{Stdin: strings.NewReader("ab\na\x03"), Stdout: &out}
+	if _, err := s.Run(&Prompt{Label: "Password", Mask: '*', ConfirmMatch: true}); err != ErrInterrupt {
+		t.Errorf("expected an interrupted confirmation to return ErrInterrupt, got %v", err)
+	}
+
+	s = Session{Stdin: strings.NewReader("ab\na\x03"), Stdout: &out}
+	s.Run(&Prompt{Label: "Password", Mask: '*', ConfirmMatch: true, InterruptExitCode: &two})
+	if code != 2 {
+		t.Errorf("expected an interrupted confirmation to exit with 2, got %d", code)
+	}
+}
@@ -10,7 +10,10 @@
 // detailed view and custom templates.
 package promptui
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // ErrEOF is the error returned from prompts when EOF is encountered.
 var ErrEOF = errors.New("^D")
@@ -19,9 +22,43 @@ var ErrEOF = errors.New("^D")
 // encountered.
 var ErrInterrupt = errors.New("^C")
 
-// ErrAbort is the error returned when confirm prompts are supplied "n"
+// ErrAbort is the error returned when confirm prompts are supplied "n", or when the AbortKey of a prompt is pressed.
 var ErrAbort = errors.New("")
 
+// ErrCanceled is the error returned when a running prompt is stopped with Cancel.
+var ErrCanceled = errors.New("canceled")
+
+// ErrMismatch is the error returned when the second entry of a prompt using ConfirmMatch fails.
+var ErrMismatch = errors.New("values do not match")
+
 // ValidateFunc is a placeholder type for any validation functions that validates a given input. It should return
 // a ValidationError if the input is not valid.
 type ValidateFunc func(string) error
+
+// ValidateDataFunc is a validation function that also returns data describing the input, like which requirements
+// are met. The data is available in the prompt templates through the data function, for example
+// `{{ data "met" }}`, and is refreshed every time the input is validated.
+type ValidateDataFunc func(string) (map[string]interface{}, error)
+
+// SuggestManyFunc is a validation function that also returns fixes for an invalid input, like the known values
+// closest to a misspelled one. The suggestions are ignored when the input is valid.
+type SuggestManyFunc func(string) (suggestions []string, err error)
+
+// IncrementalValidator validates an input from the result of the previous validation, for validations too slow
+// to check the whole input after every key. ValidateDelta is called with the last validated input and the
+// current one when the current input only appends to it, its error replacing the one of the previous input.
+type IncrementalValidator interface {
+	ValidateDelta(prev, curr string) error
+}
+
+// ValidationRecord is an entry of a prompt's ValidationTrace.
+type ValidationRecord struct {
+	// Input is the validated input.
+	Input string
+
+	// Err is the error returned by the validation, nil when the input is valid.
+	Err error
+
+	// Time is when the validation returned.
+	Time time.Time
+}
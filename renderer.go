@@ -0,0 +1,215 @@
+package promptui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/ergochat/readline"
+)
+
+// Renderer draws a prompt on its output. Prompts use an ANSI terminal renderer by default, but a custom
+// Renderer lets the prompt be displayed elsewhere, for example inside a GUI terminal emulator or a session
+// recorder. Every method receives the text produced by the prompt's templates, including their styles.
+type Renderer interface {
+	// DrawPrompt draws the prompt line while the value is being edited: the label followed by the input.
+	DrawPrompt(prompt string)
+
+	// DrawError draws the validation error displayed in place of the prompt line when a value is rejected.
+	DrawError(message string)
+
+	// DrawSuccess draws the line displayed once the prompt has ended, terminated by a line break unless
+	// NoTrailingNewline is set.
+	DrawSuccess(line string)
+
+	// HideCursor hides the terminal cursor while the prompt is running.
+	HideCursor()
+
+	// ShowCursor shows the terminal cursor again once the prompt has ended.
+	ShowCursor()
+}
+
+// bellRenderer is implemented by renderers able to ring the terminal bell, used to signal a rejected key
+// without drawing an error.
+type bellRenderer interface {
+	Bell()
+}
+
+// clearRenderer is implemented by renderers able to erase the prompt once it has ended.
+type clearRenderer interface {
+	Clear()
+}
+
+// ansiRenderer is the default Renderer, writing ANSI escape codes through readline.
+type ansiRenderer struct {
+	rl  *readline.Instance
+	out *frameWriter
+
+	// the number of line breaks in the prompt on screen, once readline printed one.
+	rows  int
+	drawn bool
+
+	// erase clears the prompt before drawing the success line, instead of leaving it above.
+	erase bool
+}
+
+func (r *ansiRenderer) DrawPrompt(prompt string) {
+	r.out.frame(func() {
+		r.setPrompt(prompt)
+		r.rl.Refresh()
+	})
+}
+
+// DrawError only replaces the prompt: readline redraws the line itself once the rejected key is processed.
+func (r *ansiRenderer) DrawError(message string) {
+	r.setPrompt(message)
+}
+
+// setPrompt replaces the prompt of readline. Before redrawing, readline moves the cursor up by the number of
+// lines of the new prompt to clear the old one, so when they don't use as many lines the cursor is first moved
+// by the difference.
+func (r *ansiRenderer) setPrompt(prompt string) {
+	rows := strings.Count(prompt, "\n")
+	if r.drawn && r.interactive() {
+		switch d := rows - r.rows; {
+		case d > 0:
+			r.out.Write([]byte(strings.Repeat("\n", d)))
+		case d < 0:
+			fmt.Fprintf(r.out, "\033[%dA", -d)
+		}
+	}
+	r.rows, r.drawn = rows, true
+
+	r.rl.SetPrompt(prompt)
+}
+
+func (r *ansiRenderer) DrawSuccess(line string) {
+	if r.erase {
+		r.Clear()
+	}
+	r.rl.Write([]byte(line))
+}
+
+// Clear is called once readline moved to the line after the prompt, which happens when it is submitted or
+// interrupted.
+func (r *ansiRenderer) Clear() {
+	if r.drawn && r.interactive() {
+		fmt.Fprintf(r.out, "%s\r%s", upLine(uint(r.rows+1)), clearScreenDown)
+	}
+}
+
+// interactive reports whether readline draws the prompt, which it doesn't when the output isn't a terminal.
+func (r *ansiRenderer) interactive() bool {
+	cfg := r.rl.GetConfig()
+	return cfg.ForceUseInteractive || cfg.FuncIsTerminal()
+}
+
+// Bell bypasses readline, which would redraw the prompt around the write.
+func (r *ansiRenderer) Bell() {
+	r.out.Write([]byte("\a"))
+}
+
+func (r *ansiRenderer) HideCursor() {
+	r.rl.Write([]byte(hideCursor))
+}
+
+func (r *ansiRenderer) ShowCursor() {
+	r.rl.Write([]byte(showCursor))
+}
+
+// frameWriter sits between readline and the output of a prompt. Readline redraws the prompt with several
+// writes, clearing the line before printing it again, which flickers on slow terminals. The writes done while
+// drawing a frame are held and sent to the output in a single write once the frame is complete. A prompt holds
+// a frame for each key, from the filter of readline until readline reads the next key with a frameReader.
+//
+// The first error returned by the output, like a broken pipe, is kept and returned by the writes following it
+// without writing anything. failed is called with it, once.
+type frameWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	buf     bytes.Buffer
+	holding bool
+	err     error
+	failed  func(error)
+}
+
+func (f *frameWriter) Write(b []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.holding {
+		return f.buf.Write(b)
+	}
+	return f.write(b)
+}
+
+// write writes b to the output unless it already failed. f.mu must be held.
+func (f *frameWriter) write(b []byte) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+
+	n, err := f.w.Write(b)
+	if err != nil {
+		f.err = err
+		if f.failed != nil {
+			f.failed(err)
+		}
+	}
+	return n, err
+}
+
+// failure returns the error the output failed with, if any.
+func (f *frameWriter) failure() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.err
+}
+
+// frame calls draw, writing everything it outputs at once. Within a frame already held, it only adds to it.
+func (f *frameWriter) frame(draw func()) {
+	f.mu.Lock()
+	held := f.holding
+	f.holding = true
+	f.mu.Unlock()
+
+	draw()
+
+	if !held {
+		f.flush()
+	}
+}
+
+// hold starts a frame, holding the writes until flush.
+func (f *frameWriter) hold() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.holding = true
+}
+
+// flush ends the frame, writing everything held at once.
+func (f *frameWriter) flush() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.holding = false
+	if f.buf.Len() != 0 {
+		f.write(f.buf.Bytes())
+		f.buf.Reset()
+	}
+}
+
+// frameReader is the input of readline, flushing the frame of the last key once readline waits for the next one.
+type frameReader struct {
+	r   io.Reader
+	out *frameWriter
+}
+
+func (f frameReader) Read(b []byte) (int, error) {
+	f.out.flush()
+	return f.r.Read(b)
+}
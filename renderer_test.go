@@ -0,0 +1,140 @@
+package promptui
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ergochat/readline"
+)
+
+type countingWriter struct {
+	writes []string
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	w.writes = append(w.writes, string(b))
+	return len(b), nil
+}
+
+func TestFrameWriter(t *testing.T) {
+	w := &countingWriter{}
+	f := &frameWriter{w: w}
+
+	f.Write([]byte("before"))
+	f.frame(func() {
+		f.Write([]byte("clean"))
+		f.Write([]byte("print"))
+	})
+	f.frame(func() {})
+	f.Write([]byte("after"))
+
+	expected := []string{"before", "cleanprint", "after"}
+	if len(w.writes) != len(expected) {
+		t.Fatalf("expected writes %q, got %q", expected, w.writes)
+	}
+	for i := range expected {
+		if w.writes[i] != expected[i] {
+			t.Errorf("expected write %d to be %q, got %q", i, expected[i], w.writes[i])
+		}
+	}
+}
+
+func TestAnsiRendererClear(t *testing.T) {
+	var buf bytes.Buffer
+	out := &frameWriter{w: &buf}
+	rl, err := readline.NewFromConfig(&readline.Config{
+		Stdin:               strings.NewReader(""),
+		Stdout:              out,
+		ForceUseInteractive: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rl.Close()
+
+	r := &ansiRenderer{rl: rl, out: out}
+	r.Clear()
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing to be cleared before drawing, got %q", buf.String())
+	}
+
+	r.setPrompt("label\ninput")
+	r.Clear()
+	if got := buf.String(); got != "\x1b[2A\r\x1b[J" {
+		t.Errorf("expected the two lines of the prompt to be cleared, got %q", got)
+	}
+}
+
+// keyWriter counts the writes to the output between the reads of keyReader.
+type keyWriter struct {
+	mu     sync.Mutex
+	writes int
+}
+
+func (w *keyWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.writes++
+	return len(b), nil
+}
+
+// keyReader returns a key per read, like a terminal, recording the writes done since the previous read.
+type keyReader struct {
+	w      *keyWriter
+	keys   []string
+	counts []int
+}
+
+func (r *keyReader) Read(b []byte) (int, error) {
+	r.w.mu.Lock()
+	r.counts = append(r.counts, r.w.writes)
+	r.w.writes = 0
+	r.w.mu.Unlock()
+
+	if len(r.keys) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(b, r.keys[0])
+	r.keys = r.keys[1:]
+	return n, nil
+}
+
+func TestPromptFramePerKey(t *testing.T) {
+	defer func(force bool) { forceInteractive = force }(forceInteractive)
+	forceInteractive = true
+
+	w := &keyWriter{}
+	// readline first asks the terminal for the position of the cursor.
+	keys := []string{"\x1b[1;1R", "a", "\r", "b", "\x7f", "c", "\r"}
+	r := &keyReader{w: w, keys: keys}
+	p := Prompt{
+		Label: "Name",
+		Validate: func(s string) error {
+			if len(s) < 2 {
+				return errors.New("too short")
+			}
+			return nil
+		},
+		Stdin:  r,
+		Stdout: w,
+	}
+
+	if value, err := p.Run(); err != nil || value != "ac" {
+		t.Fatalf("expected ac, got %q, %v", value, err)
+	}
+
+	// each read after the cursor position flushes the frame of the key read before, the rejected Enter included.
+	if len(r.counts) < len(keys) {
+		t.Fatalf("expected every key to be read, got %v", r.counts)
+	}
+	for i, n := range r.counts[2:len(keys)] {
+		if n != 1 {
+			t.Errorf("expected a single write for key %q, got %d", keys[i+1], n)
+		}
+	}
+}
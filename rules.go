@@ -0,0 +1,114 @@
+package promptui
+
+import "errors"
+
+// Severity is how a Rule the input doesn't meet affects the prompt.
+type Severity int
+
+const (
+	// SeverityError rejects the input. It is the default severity of a Rule.
+	SeverityError Severity = iota
+
+	// SeverityWarning reports the rule as a warning, the input being accepted anyway.
+	SeverityWarning
+)
+
+// Rule is a named requirement checked by Validators.
+type Rule struct {
+	// Name describes the requirement in the checklist, like "at least 8 characters".
+	Name string
+
+	// Validate returns an error when the input doesn't meet the requirement.
+	Validate ValidateFunc
+
+	// Severity tells whether the input is rejected when it doesn't meet the requirement.
+	Severity Severity
+}
+
+// RuleResult is the state of a Rule for the current input.
+type RuleResult struct {
+	// Name is the name of the rule.
+	Name string
+
+	// Err is the error returned by the rule, nil when the input meets it.
+	Err error
+
+	// Severity is the severity of the rule.
+	Severity Severity
+
+	// Current reports whether this is the first failing rule, whose error is the one returned by the validation.
+	Current bool
+}
+
+// Validators combines rules into a single validation function, usable as a prompt's ValidateData. The input is
+// valid when it meets every rule of SeverityError, otherwise the error of the first of them failing is returned.
+// The rules of SeverityWarning are all checked too, without rejecting the input.
+//
+// The results of all the rules are available to the templates as a []RuleResult with `{{ data "rules" }}`, and
+// the failing warnings alone with `{{ data "warnings" }}`. Setting ValidationChecklist on the prompt displays the
+// rules as a checklist under the input and ValidationWarnings lists the failing warnings.
+func Validators(rules ...Rule) ValidateDataFunc {
+	return func(input string) (map[string]interface{}, error) {
+		results := make([]RuleResult, len(rules))
+		var warnings []RuleResult
+
+		var first error
+		for i, rule := range rules {
+			err := rule.Validate(input)
+			results[i] = RuleResult{
+				Name:     rule.Name,
+				Err:      err,
+				Severity: rule.Severity,
+				Current:  err != nil && first == nil && rule.Severity == SeverityError,
+			}
+
+			switch {
+			case results[i].Current:
+				first = err
+			case err != nil && rule.Severity == SeverityWarning:
+				warnings = append(warnings, results[i])
+			}
+		}
+
+		return map[string]interface{}{"rules": results, "warnings": warnings}, first
+	}
+}
+
+// Requirement is a condition displayed in the checklist of a prompt's Requirements.
+type Requirement struct {
+	// Label describes the condition in the checklist, like "8+ chars".
+	Label string
+
+	// Check reports whether the input meets the condition.
+	Check func(input string) bool
+}
+
+// checkRequirements checks each requirement against the input, like Validators does with rules. An unmet
+// requirement fails with its label as the error.
+func checkRequirements(requirements []Requirement, input string) []RuleResult {
+	results := make([]RuleResult, len(requirements))
+	first := true
+	for i, r := range requirements {
+		results[i].Name = r.Label
+		if !r.Check(input) {
+			results[i].Err = errors.New(r.Label)
+			results[i].Current = first
+			first = false
+		}
+	}
+	return results
+}
+
+// unmetRequirement returns the error of the first requirement the input doesn't meet when EnforceRequirements
+// is set.
+func (p *Prompt) unmetRequirement(input string) error {
+	if !p.EnforceRequirements {
+		return nil
+	}
+	for _, r := range checkRequirements(p.Requirements, input) {
+		if r.Current {
+			return r.Err
+		}
+	}
+	return nil
+}
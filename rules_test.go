@@ -0,0 +1,153 @@
+package promptui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	short := errors.New("too short")
+	digit := errors.New("no digit")
+
+	validate := Validators(
+		Rule{Name: "a letter", Validate: func(s string) error { return nil }},
+		Rule{Name: "4 characters", Validate: func(s string) error {
+			if len(s) < 4 {
+				return short
+			}
+			return nil
+		}},
+		Rule{Name: "a digit", Validate: func(s string) error { return digit }},
+	)
+
+	data, err := validate("abc")
+	if err != short {
+		t.Errorf("expected the error of the first failing rule, got %v", err)
+	}
+
+	rules, ok := data["rules"].([]RuleResult)
+	if !ok || len(rules) != 3 {
+		t.Fatalf("expected the results of the 3 rules, got %v", data["rules"])
+	}
+
+	expected := []RuleResult{
+		{Name: "a letter"},
+		{Name: "4 characters", Err: short, Current: true},
+		{Name: "a digit", Err: digit},
+	}
+	for i, r := range rules {
+		if r != expected[i] {
+			t.Errorf("expected rule %d to be %+v, got %+v", i, expected[i], r)
+		}
+	}
+}
+
+func TestValidatorsSeverity(t *testing.T) {
+	weak := errors.New("weak")
+	short := errors.New("too short")
+
+	validate := Validators(
+		Rule{Name: "a symbol", Severity: SeverityWarning, Validate: func(s string) error { return weak }},
+		Rule{Name: "4 characters", Validate: func(s string) error {
+			if len(s) < 4 {
+				return short
+			}
+			return nil
+		}},
+	)
+
+	data, err := validate("abc")
+	if err != short {
+		t.Errorf("expected the error rule to reject the input, got %v", err)
+	}
+	warnings, _ := data["warnings"].([]RuleResult)
+	if len(warnings) != 1 || warnings[0].Err != weak || warnings[0].Current {
+		t.Errorf("expected the failing warning to be reported, got %+v", warnings)
+	}
+
+	if _, err := validate("abcd"); err != nil {
+		t.Errorf("expected warnings to accept the input, got %v", err)
+	}
+}
+
+func TestPromptValidationWarnings(t *testing.T) {
+	p := Prompt{
+		Label:              "Password",
+		ValidationWarnings: true,
+		ValidateData: Validators(Rule{Name: "a symbol", Severity: SeverityWarning, Validate: func(s string) error {
+			return errors.New("no symbol")
+		}}),
+		Templates: &PromptTemplates{ValidationWarning: "\n{{ .Name }}: {{ .Err }}"},
+		Pointer:   PipeCursor,
+	}
+
+	rendered, _, _, _ := p.Update('a')
+	if !strings.HasSuffix(rendered, "a|\na symbol: no symbol") {
+		t.Errorf("expected the warning under the input, got %q", rendered)
+	}
+
+	if _, done, value, err := p.Update(KeyEnter); !done || value != "a" || err != nil {
+		t.Errorf("expected the input to be accepted, got %q, %v", value, err)
+	}
+}
+
+func TestPromptRequirements(t *testing.T) {
+	requirements := []Requirement{
+		{Label: "2+ chars", Check: func(s string) bool { return len(s) >= 2 }},
+		{Label: "a digit", Check: func(s string) bool { return strings.ContainsAny(s, "0123456789") }},
+	}
+	checklist := `{{ range . }}{{ "\n" }}{{ if .Err }}x{{ else }}v{{ end }} {{ .Name }}{{ end }}`
+
+	p := Prompt{
+		Label:     "Password",
+		Templates: &PromptTemplates{Checklist: checklist},
+		Pointer:   PipeCursor,
+	}
+	p.Requirements = requirements
+
+	rendered, _, _, _ := p.Update('a')
+	if !strings.HasSuffix(rendered, " \nx 2+ chars\nx a digit\na|") {
+		t.Errorf("expected the checklist between the label and the input, got %q", rendered)
+	}
+	rendered, _, _, _ = p.Update('1')
+	if !strings.HasSuffix(rendered, " \nv 2+ chars\nv a digit\na1|") {
+		t.Errorf("expected the checklist to be updated, got %q", rendered)
+	}
+
+	p = Prompt{Label: "Password", Requirements: requirements, Templates: &PromptTemplates{ValidationError: "{{ . }}"}}
+	p.Update('a')
+	if _, done, value, _ := p.Update(KeyEnter); !done || value != "a" {
+		t.Errorf("expected the requirements to only inform, got %q", value)
+	}
+
+	p = Prompt{
+		Label:               "Password",
+		Requirements:        requirements,
+		EnforceRequirements: true,
+		Templates:           &PromptTemplates{ValidationError: "{{ . }}"},
+	}
+	p.Update('a')
+	p.Update('b')
+	if rendered, done, _, _ := p.Update(KeyEnter); done || rendered != "a digit" {
+		t.Errorf("expected the first unmet requirement to reject the input, got %q", rendered)
+	}
+
+	v := &lengthValidator{max: 3}
+	p = Prompt{
+		Label:               "Password",
+		Requirements:        requirements,
+		EnforceRequirements: true,
+		Validate:            v.Validate,
+		ValidateIncremental: v,
+		Templates:           &PromptTemplates{ValidationError: "{{ . }}"},
+	}
+	p.Update('a')
+	p.Update('b')
+	if rendered, done, _, _ := p.Update(KeyEnter); done || rendered != "a digit" {
+		t.Errorf("expected the requirements to be enforced with ValidateIncremental, got %q", rendered)
+	}
+	if v.length != 2 {
+		t.Errorf("expected the incremental validation to keep up with the input, got length %d", v.length)
+	}
+}
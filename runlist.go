@@ -0,0 +1,119 @@
+package promptui
+
+import (
+	"errors"
+	"strings"
+)
+
+// DuplicatePolicy tells how RunList handles an entry entered more than once.
+type DuplicatePolicy int
+
+const (
+	// DuplicatesAllow returns every entry, repeated ones included. It is the default.
+	DuplicatesAllow DuplicatePolicy = iota
+
+	// DuplicatesDrop only returns the first occurrence of each entry.
+	DuplicatesDrop
+
+	// DuplicatesReject rejects the input while an entry is repeated, with ErrDuplicate as the validation error.
+	DuplicatesReject
+)
+
+// ErrDuplicate is the validation error of a list entered with RunList repeating an entry, when the prompt's
+// ListDuplicates is DuplicatesReject.
+var ErrDuplicate = errors.New("duplicate entry")
+
+// RunList runs the prompt for a list of entries separated by delimiter, like comma-separated tags, and returns
+// them trimmed of their surrounding spaces, empty entries left out. The entries before the last delimiter are
+// displayed as chips, and Backspace right after a delimiter removes the last chip. The validation of the prompt
+// applies to each entry, the first rejected one giving the error.
+func (p *Prompt) RunList(delimiter rune) ([]string, error) {
+	delim := p.listDelim
+	defer func() { p.listDelim = delim }()
+
+	p.listDelim = delimiter
+	value, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+	return p.listEntries(value), nil
+}
+
+// listEntries splits the value of a prompt run by RunList, applying ListDuplicates.
+func (p *Prompt) listEntries(value string) []string {
+	var entries []string
+	seen := make(map[string]bool)
+	for _, e := range strings.Split(value, string(p.listDelim)) {
+		e = strings.TrimSpace(e)
+		if e == "" || (p.ListDuplicates == DuplicatesDrop && seen[e]) {
+			continue
+		}
+		seen[e] = true
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// validateList applies validate to each entry of a list entered with RunList.
+func (p *Prompt) validateList(validate ValidateFunc) ValidateFunc {
+	return func(input string) error {
+		seen := make(map[string]bool)
+		for _, e := range p.listEntries(input) {
+			if err := validate(e); err != nil {
+				return err
+			}
+			if p.ListDuplicates == DuplicatesReject && seen[e] {
+				return ErrDuplicate
+			}
+			seen[e] = true
+		}
+		return nil
+	}
+}
+
+// chips renders the input of a prompt run by RunList, the entries before the last delimiter as chips. The whole
+// input is displayed as text while the cursor is on one of them, so it can be edited.
+func (s *editState) chips(input []rune) string {
+	last := -1
+	for i, r := range input {
+		if r == s.p.listDelim {
+			last = i
+		}
+	}
+	if last < 0 || s.cur.Position <= last {
+		return format(input, &s.cur)
+	}
+
+	var sb strings.Builder
+	for _, e := range strings.Split(string(input[:last]), string(s.p.listDelim)) {
+		if e = strings.TrimSpace(e); e != "" {
+			sb.WriteString(Styler(BGBlue, FGWhite)(" "+e+" ") + " ")
+		}
+	}
+
+	// the space usually typed after the delimiter is already after the last chip.
+	current := input[last+1:]
+	cur := Cursor{Cursor: s.cur.Cursor, Position: s.cur.Position - last - 1}
+	for len(current) != 0 && current[0] == ' ' && cur.Position > 0 {
+		current = current[1:]
+		cur.Position--
+	}
+	sb.WriteString(format(current, &cur))
+	return sb.String()
+}
+
+// removeChip removes the last entry of a list and the delimiter following it when the cursor is right after the
+// delimiter, at the end of the input, reporting whether it did.
+func (s *editState) removeChip() bool {
+	input := s.cur.Get()
+	i := strings.LastIndex(input, string(s.p.listDelim))
+	if s.cur.erase || s.cur.Position != len(s.cur.input) || i < 0 || strings.TrimSpace(input[i+1:]) != "" {
+		return false
+	}
+
+	// the spaces typed after the previous delimiter are kept for the next entry.
+	j := strings.LastIndex(input[:i], string(s.p.listDelim))
+	removed := input[j+1 : i]
+	s.cur.Replace(input[:j+1] + removed[:len(removed)-len(strings.TrimLeft(removed, " "))])
+	return true
+}
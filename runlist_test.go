@@ -0,0 +1,155 @@
+package promptui
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestPromptRunList(t *testing.T) {
+	cases := []struct {
+		name       string
+		duplicates DuplicatePolicy
+		expected   string
+	}{
+		{"keeps duplicates", DuplicatesAllow, "go|cli|go"},
+		{"drops duplicates", DuplicatesDrop, "go|cli"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := Prompt{
+				Label:          "Tags",
+				ListDuplicates: c.duplicates,
+				Stdin:          strings.NewReader(" go, cli,,go \n"),
+				Stdout:         &bytes.Buffer{},
+			}
+			entries, err := p.RunList(',')
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if got := strings.Join(entries, "|"); got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestPromptRunAfterRunList(t *testing.T) {
+	p := Prompt{
+		Label:   "Tags",
+		Pointer: PipeCursor,
+		Stdin:   strings.NewReader("x, y\n"),
+		Stdout:  &bytes.Buffer{},
+		Validate: func(s string) error {
+			if strings.Contains(s, ",") {
+				return errors.New("no commas")
+			}
+			return nil
+		},
+	}
+	if _, err := p.RunList(','); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	p.Stdin = strings.NewReader("x, y\n")
+	if value, err := p.Run(); err == nil {
+		t.Errorf("expected Run to validate the whole input once RunList returned, got %q", value)
+	}
+
+	rendered, _, _, _ := p.Update('x')
+	for _, key := range ", y" {
+		rendered, _, _, _ = p.Update(key)
+	}
+	if !strings.HasSuffix(rendered, "x, y|") {
+		t.Errorf("expected the input as text once RunList returned, got %q", rendered)
+	}
+}
+
+func TestPromptListEditing(t *testing.T) {
+	lower := errors.New("must be lowercase")
+	p := Prompt{
+		Label:          "Tags",
+		Pointer:        PipeCursor,
+		ListDuplicates: DuplicatesReject,
+		Templates:      &PromptTemplates{ValidationError: "{{ . }}"},
+		Validate: func(s string) error {
+			if strings.IndexFunc(s, unicode.IsUpper) >= 0 {
+				return lower
+			}
+			return nil
+		},
+		listDelim: ',',
+	}
+
+	var rendered string
+	for _, key := range "go, cli, b" {
+		rendered, _, _, _ = p.Update(key)
+	}
+	chip := Styler(BGBlue, FGWhite)
+	if !strings.HasSuffix(rendered, chip(" go ")+" "+chip(" cli ")+" b|") {
+		t.Errorf("expected the entries as chips, got %q", rendered)
+	}
+
+	p.Update(KeyBackspace)
+	p.Update(KeyBackspace)
+	if value := p.editing.cur.Get(); value != "go, " {
+		t.Errorf("expected Backspace after the delimiter to remove the last chip, got %q", value)
+	}
+
+	for _, key := range "Go" {
+		p.Update(key)
+	}
+	if rendered, done, _, _ := p.Update(KeyEnter); done || rendered != lower.Error() {
+		t.Errorf("expected each entry to be validated, got %q", rendered)
+	}
+
+	p.Update(KeyBackspace)
+	p.Update(KeyBackspace)
+	p.Update('g')
+	p.Update('o')
+	if rendered, done, _, _ := p.Update(KeyEnter); done || rendered != ErrDuplicate.Error() {
+		t.Errorf("expected the duplicate to be rejected, got %q", rendered)
+	}
+}
+
+// acceptDeltas is an incremental validator accepting every appended input.
+type acceptDeltas struct{}
+
+func (acceptDeltas) ValidateDelta(prev, curr string) error {
+	return nil
+}
+
+func TestPromptListValidateIncremental(t *testing.T) {
+	bad := errors.New("bad entry")
+	p := Prompt{
+		Label:               "Tags",
+		ListDuplicates:      DuplicatesReject,
+		Templates:           &PromptTemplates{ValidationError: "{{ . }}"},
+		ValidateIncremental: acceptDeltas{},
+		Validate: func(s string) error {
+			if s == "bad" {
+				return bad
+			}
+			return nil
+		},
+		listDelim: ',',
+	}
+
+	for _, key := range "a,bad" {
+		p.Update(key)
+	}
+	if rendered, done, _, _ := p.Update(KeyEnter); done || rendered != bad.Error() {
+		t.Errorf("expected each entry to be validated with ValidateIncremental, got %q", rendered)
+	}
+
+	for range "bad" {
+		p.Update(KeyBackspace)
+	}
+	p.Update('a')
+	if rendered, done, _, _ := p.Update(KeyEnter); done || rendered != ErrDuplicate.Error() {
+		t.Errorf("expected the duplicate to be rejected with ValidateIncremental, got %q", rendered)
+	}
+}
@@ -8,6 +8,9 @@ import (
 )
 
 func TestSelectTemplateRender(t *testing.T) {
+	defer func(icons IconSet) { Icons = icons }(Icons)
+	Icons = IconsUnicode
+
 	t.Run("when using default style", func(t *testing.T) {
 		values := []string{"Zero"}
 		s := Select{
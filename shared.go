@@ -0,0 +1,95 @@
+package promptui
+
+import (
+	"io"
+	"os"
+
+	"github.com/ergochat/readline"
+)
+
+// Session runs a series of prompts on the same line editor, which Run otherwise sets up and tears down for
+// each prompt. The cursor stays hidden between the prompts instead of flickering, and keys typed ahead while a
+// prompt ends are kept for the next one. The zero value reads the standard input and writes to the standard
+// output, and Close must be called once the prompts are done to restore the terminal.
+//
+//	var s promptui.Session
+//	defer s.Close()
+//
+//	name, err := s.Run(&promptui.Prompt{Label: "Name"})
+//	...
+//	email, err := s.Run(&promptui.Prompt{Label: "Email"})
+//
+// The prompts run one at a time and read Stdin and write to Stdout of the session, ignoring their own, and their
+// DemoScript. Canceling a prompt, or submitting its default with AutoSubmitDefault, tears the line editor down;
+// the next prompt sets up a new one.
+type Session struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+
+	rl  *readline.Instance
+	out *frameWriter
+	esc *escapeReader
+}
+
+// Run runs p like its Run method, reusing the line editor of the session.
+func (s *Session) Run(p *Prompt) (string, error) {
+	p.shared = s
+	defer func() { p.shared = nil }()
+
+	return p.Run()
+}
+
+// Close shows the cursor again and closes the line editor. The session can still run prompts afterwards,
+// setting up a new line editor.
+func (s *Session) Close() error {
+	if s.rl == nil {
+		return nil
+	}
+
+	s.rl.Write([]byte(showCursor))
+	err := s.rl.Close()
+	s.rl = nil
+	return err
+}
+
+// writer returns the output of the session, shared by its prompts.
+func (s *Session) writer() *frameWriter {
+	if s.out == nil {
+		stdout := s.Stdout
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		s.out = &frameWriter{w: stdout}
+	}
+	return s.out
+}
+
+// reader returns the input of the session for a prompt, converting its lone ESC keys when esc is set, in vim mode
+// when vim is set. The line editor keeps reading the input it was set up with, so the same reader serves all the
+// prompts running on it.
+func (s *Session) reader(in io.Reader, esc, vim bool) io.Reader {
+	if s.rl == nil {
+		s.esc = newEscapeReader(in, vim)
+	}
+	s.esc.set(esc, vim)
+	return s.esc
+}
+
+// instance returns the line editor of the session configured with c, setting it up for the first prompt.
+func (s *Session) instance(c *readline.Config) (*readline.Instance, error) {
+	if s.rl != nil {
+		return s.rl, s.rl.SetConfig(c)
+	}
+
+	rl, err := readline.NewFromConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	s.rl = rl
+	return rl, nil
+}
+
+// discard forgets the line editor once a prompt closed it, so the next prompt sets up a new one.
+func (s *Session) discard() {
+	s.rl = nil
+}
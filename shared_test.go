@@ -0,0 +1,72 @@
+package promptui
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSession(t *testing.T) {
+	var out bytes.Buffer
+	s := Session{Stdin: strings.NewReader("john\nsmith\n"), Stdout: &out}
+
+	values := make([]string, 0, 2)
+	for _, label := range []string{"First name", "Last name"} {
+		value, err := s.Run(&Prompt{Label: label})
+		if err != nil {
+			t.Fatalf("%s: %v", label, err)
+		}
+		values = append(values, value)
+	}
+
+	if got := strings.Join(values, " "); got != "john smith" {
+		t.Errorf("expected both lines to be read, got %q", got)
+	}
+	if strings.Contains(out.String(), showCursor) {
+		t.Errorf("expected the cursor to stay hidden between the prompts, got %q", out.String())
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(out.String(), showCursor) {
+		t.Errorf("expected Close to show the cursor, got %q", out.String())
+	}
+
+	if _, err := s.Run(&Prompt{Label: "Middle name"}); err != ErrEOF {
+		t.Errorf("expected the end of the input after the session was closed, got %v", err)
+	}
+}
+
+func TestSessionVimMode(t *testing.T) {
+	stdin, keys := io.Pipe()
+	defer keys.Close()
+
+	s := Session{Stdin: stdin, Stdout: &bytes.Buffer{}}
+	defer s.Close()
+
+	// the keys of each prompt are typed once it runs, as the line editor reads ahead.
+	go keys.Write([]byte("john\n"))
+	if value, err := s.Run(&Prompt{Label: "Name"}); err != nil || value != "john" {
+		t.Fatalf("expected john, got %q, %v", value, err)
+	}
+
+	p := &Prompt{Label: "Code", IsVimMode: true}
+	go func() {
+		for {
+			activeMu.Lock()
+			running := p.active != nil
+			activeMu.Unlock()
+			if running {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		keys.Write([]byte("ab\x1bhhx\r"))
+	}()
+	if value, err := s.Run(p); err != nil || value != "b" {
+		t.Errorf("expected ESC to switch the second prompt to the normal mode, got %q, %v", value, err)
+	}
+}
@@ -0,0 +1,68 @@
+package promptui
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// spinnerInterval is the delay between two frames of the spinner. A DefaultFunc returning faster than this
+// doesn't display the spinner at all.
+const spinnerInterval = 80 * time.Millisecond
+
+var (
+	unicodeSpinner = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	asciiSpinner   = []string{"|", "/", "-", "\\"}
+)
+
+// loadDefault runs DefaultFunc in the background and returns its value, animating a spinner after the
+// label on w until it returns. The line is cleared before returning so the prompt can be painted over it.
+func (p *Prompt) loadDefault(w io.Writer) string {
+	var value string
+	spin(p.timeSource(), w, p.renderLabel(p.Templates.prompt), func() {
+		value = p.DefaultFunc()
+	})
+	return value
+}
+
+// finalizeValue runs the finalizer of RunWithFinalizer on the submitted value, animating a spinner after the
+// success line on w until it returns.
+func (p *Prompt) finalizeValue(w io.Writer, value string) error {
+	var err error
+	spin(p.timeSource(), w, p.successLine(value, 0), func() {
+		err = p.finalize(value)
+	})
+	return err
+}
+
+// spin runs fn in the background, animating a spinner after label on w until it returns. The line is cleared
+// before returning if the spinner was displayed.
+func spin(c clock, w io.Writer, label []byte, fn func()) {
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+
+	frames := asciiSpinner
+	if supportsUnicode() {
+		frames = unicodeSpinner
+	}
+
+	spun := false
+	tick := c.After(spinnerInterval)
+
+	for i := 0; ; i++ {
+		select {
+		case <-done:
+			if spun {
+				io.WriteString(w, "\r"+clearLine)
+			}
+			return
+		case <-tick:
+			fmt.Fprintf(w, "\r%s%s%s", clearLine, label, Styler(FGFaint)(frames[i%len(frames)]))
+			spun = true
+			tick = c.After(spinnerInterval)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package promptui
+
+// PromptState is a snapshot of the input of a prompt, which can be saved to resume the prompt later, for
+// example when a program is suspended with Ctrl-Z. Its fields are exported so it can be serialized.
+type PromptState struct {
+	// Input is the value being edited.
+	Input string
+
+	// Position is the position of the cursor in the input, in runes.
+	Position int
+}
+
+// State returns the state of the running prompt, whether it was started by Run or is driven with Update. When
+// the prompt isn't running, it returns the state the next run starts from.
+func (p *Prompt) State() PromptState {
+	activeMu.Lock()
+	s := p.running
+	activeMu.Unlock()
+
+	if s == nil {
+		s = p.editing
+	}
+	if s == nil {
+		if p.restored != nil {
+			return *p.restored
+		}
+		return PromptState{Input: p.Default, Position: len([]rune(p.Default))}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return PromptState{Input: s.cur.Get(), Position: s.cur.Position}
+}
+
+// RestoreState replaces the input of the prompt with a state returned by State. A running prompt is redrawn
+// right away, which also makes RestoreState(p.State()) redraw a prompt after the terminal was resumed, on
+// SIGCONT. Otherwise the next run starts from the state instead of the default.
+func (p *Prompt) RestoreState(state PromptState) {
+	activeMu.Lock()
+	s := p.running
+	activeMu.Unlock()
+
+	if s == nil {
+		s = p.editing
+	}
+	if s == nil {
+		p.restored = &state
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.restore(state)
+	s.paint()
+}
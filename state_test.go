@@ -0,0 +1,51 @@
+package promptui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPromptState(t *testing.T) {
+	t.Run("saves and restores the input", func(t *testing.T) {
+		p := Prompt{Label: "Name", Pointer: PipeCursor}
+		for _, key := range []rune{'a', 'b', 'c', KeyBackward} {
+			p.Update(key)
+		}
+
+		state := p.State()
+		if state != (PromptState{Input: "abc", Position: 2}) {
+			t.Fatalf("expected the input and cursor, got %+v", state)
+		}
+
+		resumed := Prompt{Label: "Name", Pointer: PipeCursor}
+		resumed.RestoreState(state)
+
+		rendered, _, _, _ := resumed.Update(0)
+		if !strings.HasSuffix(rendered, "ab|c") {
+			t.Errorf("expected the restored input, got %q", rendered)
+		}
+	})
+
+	t.Run("continues editing after a restore", func(t *testing.T) {
+		p := Prompt{
+			Label:   "Name",
+			Default: "default",
+			Stdin:   strings.NewReader("d\n"),
+			Stdout:  &bytes.Buffer{},
+		}
+		p.RestoreState(PromptState{Input: "abc", Position: 3})
+
+		value, err := p.Run()
+		if err != nil || value != "abcd" {
+			t.Errorf("expected the restored input to be edited, got %q, %v", value, err)
+		}
+	})
+
+	t.Run("defaults to the default", func(t *testing.T) {
+		p := Prompt{Label: "Name", Default: "john"}
+		if state := p.State(); state != (PromptState{Input: "john", Position: 4}) {
+			t.Errorf("expected the default, got %+v", state)
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package promptui
+
+// StepInfo locates a prompt in a set of prompts run one after the other, like the steps of a wizard. The
+// default templates display it before the label, as in "[2/5]", and custom templates can use it with the
+// step function: `{{ with step }}Step {{ .Index }} of {{ .Total }}{{ end }}`.
+type StepInfo struct {
+	// Index is the position of the prompt in the set, starting at 1.
+	Index int
+
+	// Total is the number of prompts in the set. It is zero for a prompt run on its own.
+	Total int
+}
+
+// RunSteps runs the given prompts in order, setting the Step of each to its position among them, and returns
+// their values. It stops at the first error, returning the values collected until then.
+func RunSteps(prompts ...*Prompt) ([]string, error) {
+	values := make([]string, 0, len(prompts))
+	for i, p := range prompts {
+		p.Step = StepInfo{Index: i + 1, Total: len(prompts)}
+
+		value, err := p.Run()
+		if err != nil {
+			return values, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
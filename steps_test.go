@@ -0,0 +1,49 @@
+package promptui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunSteps(t *testing.T) {
+	var out bytes.Buffer
+	prompts := []*Prompt{
+		{Label: "Name", Stdin: strings.NewReader("john\n"), Stdout: &out},
+		{Label: "City", Stdin: strings.NewReader("paris\n"), Stdout: &out},
+		{Label: "Age", Stdin: strings.NewReader("\x03"), Stdout: &out},
+	}
+
+	values, err := RunSteps(prompts...)
+	if err != ErrInterrupt {
+		t.Errorf("expected the interrupt of the last step, got %v", err)
+	}
+	if strings.Join(values, ",") != "john,paris" {
+		t.Errorf("expected the values of the first steps, got %v", values)
+	}
+	if step := prompts[1].Step; step != (StepInfo{Index: 2, Total: 3}) {
+		t.Errorf("expected the second step of three, got %+v", step)
+	}
+	if !strings.Contains(out.String(), "[2/3]") {
+		t.Errorf("expected the progress to be displayed, got %q", out.String())
+	}
+}
+
+func TestStepTemplate(t *testing.T) {
+	r := &recordingRenderer{}
+	p := Prompt{
+		Label:     "Name",
+		Step:      StepInfo{Index: 1, Total: 4},
+		Templates: &PromptTemplates{Valid: "{{ with step }}{{ .Index }} of {{ .Total }}{{ end }} {{ . }}: "},
+		Renderer:  r,
+		Stdin:     strings.NewReader("\n"),
+		Stdout:    &bytes.Buffer{},
+	}
+
+	if _, err := p.Run(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !strings.HasPrefix(r.prompt, "1 of 4 Name: ") {
+		t.Errorf("expected the step in the label, got %q", r.prompt)
+	}
+}
@@ -11,14 +11,20 @@ var (
 	IconInitial = Styler(FGBlue)("?")
 
 	// IconGood is the icon used when a good answer is entered in prompt mode.
-	IconGood = Styler(FGGreen)("✔")
+	IconGood = unicodeIconGood
 
 	// IconWarn is the icon used when a good, but potentially invalid answer is entered in prompt mode.
-	IconWarn = Styler(FGYellow)("⚠")
+	IconWarn = unicodeIconWarn
 
 	// IconBad is the icon used when a bad answer is entered in prompt mode.
-	IconBad = Styler(FGRed)("✗")
+	IconBad = unicodeIconBad
 
 	// IconSelect is the icon used to identify the currently selected item in select mode.
-	IconSelect = Styler(FGBold)("▸")
+	IconSelect = unicodeIconSelect
 )
+
+// unicodeByDefault reports whether Unicode icons are used when the environment gives no hint about the
+// terminal capabilities.
+func unicodeByDefault() bool {
+	return true
+}
@@ -1,5 +1,7 @@
 package promptui
 
+import "os"
+
 // These are the default icons used bu promptui for select and prompts. They can either be overridden directly
 // from these variable or customized through the use of custom templates
 var (
@@ -8,14 +10,20 @@ var (
 	IconInitial = Styler(FGBlue)("?")
 
 	// IconGood is the icon used when a good answer is entered in prompt mode.
-	IconGood = Styler(FGGreen)("v")
+	IconGood = asciiIconGood
 
 	// IconWarn is the icon used when a good, but potentially invalid answer is entered in prompt mode.
-	IconWarn = Styler(FGYellow)("!")
+	IconWarn = asciiIconWarn
 
 	// IconBad is the icon used when a bad answer is entered in prompt mode.
-	IconBad = Styler(FGRed)("x")
+	IconBad = asciiIconBad
 
 	// IconSelect is the icon used to identify the currently selected item in select mode.
-	IconSelect = Styler(FGBold)(">")
+	IconSelect = asciiIconSelect
 )
+
+// unicodeByDefault reports whether Unicode icons are used when the environment gives no hint about the
+// terminal capabilities. Only Windows Terminal is known to support them, legacy consoles don't.
+func unicodeByDefault() bool {
+	return os.Getenv("WT_SESSION") != ""
+}
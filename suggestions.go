@@ -0,0 +1,41 @@
+package promptui
+
+import "sync"
+
+// acceptedValues are the values accepted by the prompts of the program, the most recent first, suggested by the
+// prompts using UseSessionSuggestions.
+var (
+	acceptedMu     sync.Mutex
+	acceptedValues []string
+)
+
+// rememberValue records a value accepted by a prompt, moving it first if it was already known.
+func rememberValue(value string) {
+	acceptedMu.Lock()
+	defer acceptedMu.Unlock()
+
+	for i, v := range acceptedValues {
+		if v == value {
+			acceptedValues = append(acceptedValues[:i], acceptedValues[i+1:]...)
+			break
+		}
+	}
+	acceptedValues = append([]string{value}, acceptedValues...)
+}
+
+// SessionSuggestions returns the values accepted by the prompts run so far by the program, the most recent
+// first. Masked values are never recorded.
+func SessionSuggestions() []string {
+	acceptedMu.Lock()
+	defer acceptedMu.Unlock()
+
+	return append([]string(nil), acceptedValues...)
+}
+
+// ClearSessionSuggestions forgets the values accepted by the prompts run so far.
+func ClearSessionSuggestions() {
+	acceptedMu.Lock()
+	defer acceptedMu.Unlock()
+
+	acceptedValues = nil
+}
@@ -0,0 +1,34 @@
+package promptui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSessionSuggestions(t *testing.T) {
+	ClearSessionSuggestions()
+	defer ClearSessionSuggestions()
+
+	for _, p := range []Prompt{
+		{Label: "Host", Stdin: strings.NewReader("db.local\n")},
+		{Label: "Password", Mask: '*', Stdin: strings.NewReader("secret\n")},
+		{Label: "Host", Stdin: strings.NewReader("api.local\n")},
+	} {
+		p.Stdout = &bytes.Buffer{}
+		if _, err := p.Run(); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if got := strings.Join(SessionSuggestions(), ","); got != "api.local,db.local" {
+		t.Errorf("expected the unmasked values, most recent first, got %s", got)
+	}
+
+	p := Prompt{Label: "Host", UseSessionSuggestions: true}
+	p.Update('d')
+	p.Update(KeyTab)
+	if value := p.editing.cur.Get(); value != "db.local" {
+		t.Errorf("expected a completion from the accepted values, got %q", value)
+	}
+}
@@ -0,0 +1,195 @@
+package promptui
+
+import (
+	"unicode"
+
+	"github.com/ergochat/readline"
+)
+
+// vim handles the key r for IsVimMode, reporting whether it was consumed. ESC leaves the insert mode, in which the
+// other keys are typed as usual. In the normal mode, printable keys move the cursor and edit the input like in
+// vi, and the other keys, like Enter or the arrows, are handled by the prompt; j and k are handed back as the
+// Down and Up keys.
+func (s *editState) vim(r rune) (rune, bool) {
+	if r == readline.CharEsc {
+		r = loneEscape
+	}
+
+	if s.vimInsert {
+		if r != loneEscape {
+			return r, false
+		}
+		s.vimInsert = false
+		s.paint()
+		return r, true
+	}
+
+	if op := s.vimPending; op != 0 {
+		s.vimPending = 0
+		if r != loneEscape {
+			s.vimOperate(op, r)
+		}
+		return r, true
+	}
+
+	switch r {
+	case 'j':
+		return KeyNext, false
+	case 'k':
+		return KeyPrev, false
+	}
+	if r != loneEscape && !unicode.IsPrint(r) {
+		return r, false
+	}
+
+	c := &s.cur
+	c.erase = false
+	n := len(c.input)
+
+	switch r {
+	case 'h':
+		c.Move(-1)
+	case 'l':
+		c.Move(1)
+	case '0', '^':
+		c.Start()
+	case '$':
+		c.End()
+	case 'w':
+		c.Place(nextWord(c.input, c.Position))
+	case 'b':
+		c.Place(prevWord(c.input, c.Position))
+	case 'e':
+		c.Place(wordEnd(c.input, c.Position))
+	case 'x':
+		s.vimCut(c.Position, c.Position+1)
+		if c.Position == len(c.input) {
+			c.Move(-1)
+		}
+	case 'X':
+		c.Backspace()
+	case 'd', 'c', 'r', 'f', 'F', 't', 'T':
+		s.vimPending = r
+		return r, true
+	case 'i':
+		s.vimInsert = true
+	case 'I':
+		c.Start()
+		s.vimInsert = true
+	case 'a':
+		c.Move(1)
+		s.vimInsert = true
+	case 'A':
+		c.End()
+		s.vimInsert = true
+	case 's':
+		s.vimCut(c.Position, c.Position+1)
+		s.vimInsert = true
+	case 'S':
+		s.vimCut(0, n)
+		s.vimInsert = true
+	default:
+		return r, true
+	}
+
+	if len(c.input) != n {
+		s.typed = true
+	}
+	s.paint()
+	return r, true
+}
+
+// vimOperate applies the operator op, one of the normal mode keys reading the key after them, with that key r.
+func (s *editState) vimOperate(op, r rune) {
+	c := &s.cur
+	n := len(c.input)
+
+	switch op {
+	case 'r':
+		if c.Position < n && unicode.IsPrint(r) {
+			c.input[c.Position] = r
+			s.typed = true
+		}
+	case 'f', 'F', 't', 'T':
+		c.Place(findRune(c.input, c.Position, r, op == 'F' || op == 'T', op == 't' || op == 'T'))
+	case 'd', 'c':
+		switch r {
+		case op:
+			s.vimCut(0, n)
+		case 'w':
+			s.vimCut(c.Position, nextWord(c.input, c.Position))
+		case 'h':
+			c.Backspace()
+		case 'l':
+			s.vimCut(c.Position, c.Position+1)
+		default:
+			return
+		}
+		s.typed = true
+		s.vimInsert = op == 'c'
+	}
+	s.paint()
+}
+
+// vimCut removes the runes of the input from from to to, leaving the cursor at from.
+func (s *editState) vimCut(from, to int) {
+	in := s.cur.input
+	to = min(to, len(in))
+	if from >= to {
+		return
+	}
+	s.cur.input = append(append([]rune(nil), in[:from]...), in[to:]...)
+	s.cur.Place(from)
+}
+
+// nextWord returns the position of the start of the word after the one at pos.
+func nextWord(in []rune, pos int) int {
+	for pos < len(in) && !unicode.IsSpace(in[pos]) {
+		pos++
+	}
+	for pos < len(in) && unicode.IsSpace(in[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// prevWord returns the position of the start of the word before pos.
+func prevWord(in []rune, pos int) int {
+	for pos > 0 && unicode.IsSpace(in[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !unicode.IsSpace(in[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordEnd returns the position of the last rune of the word after pos.
+func wordEnd(in []rune, pos int) int {
+	pos++
+	for pos < len(in) && unicode.IsSpace(in[pos]) {
+		pos++
+	}
+	for pos+1 < len(in) && !unicode.IsSpace(in[pos+1]) {
+		pos++
+	}
+	return min(pos, len(in))
+}
+
+// findRune returns the position of the next r after pos, or the previous one before it when reverse is set,
+// stopping next to it when before is set. It returns pos when there is none.
+func findRune(in []rune, pos int, r rune, reverse, before bool) int {
+	step := 1
+	if reverse {
+		step = -1
+	}
+	for i := pos + step; i >= 0 && i < len(in); i += step {
+		if in[i] == r {
+			if before {
+				return i - step
+			}
+			return i
+		}
+	}
+	return pos
+}
@@ -0,0 +1,142 @@
+package promptui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// ellipsis replaces the end of truncated text.
+const ellipsis = "…"
+
+// runeWidth returns the number of terminal columns used to display r. Wide east asian runes and most emojis
+// use two columns while combining marks and control characters don't use any.
+func runeWidth(r rune) int {
+	if unicode.IsControl(r) || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// escapeLen returns the length of the ANSI escape sequence starting s, or 0 if s doesn't start with one.
+func escapeLen(s string) int {
+	if !strings.HasPrefix(s, esc) {
+		return 0
+	}
+
+	// parameters and intermediate bytes are followed by a single final byte in the 0x40-0x7e range.
+	for i := len(esc); i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// DisplayWidth returns the number of terminal columns used to display s. ANSI escape sequences, like the ones
+// added by Styler, don't use any column, wide east asian runes use two and combining marks none. It helps
+// aligning styled text rendered by custom templates.
+func DisplayWidth(s string) int {
+	w := 0
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w += runeWidth(r)
+		i += size
+	}
+	return w
+}
+
+// StripANSI removes the ANSI escape sequences from s, like the styles added by Styler and the codes hiding the
+// cursor or clearing lines, leaving the plain text. It helps comparing the output of a prompt in tests or saving
+// it to logs. Line breaks and carriage returns are kept.
+func StripANSI(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		j := i + 1
+		for j < len(s) && escapeLen(s[j:]) == 0 {
+			j++
+		}
+		b.WriteString(s[i:j])
+		i = j
+	}
+	return b.String()
+}
+
+// truncateWidth shortens s to at most w columns, ending it with an ellipsis when some text had to be removed.
+// ANSI escape sequences are all kept, so that styles opened before the cut are still reset.
+func truncateWidth(s string, w int) string {
+	if DisplayWidth(s) <= w {
+		return s
+	}
+
+	var b strings.Builder
+	cols := 0
+	cut := false
+
+	for i := 0; i < len(s); {
+		if n := escapeLen(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+
+		if cut {
+			continue
+		}
+
+		rw := runeWidth(r)
+		if cols+rw > w-1 {
+			cut = true
+			if w > 0 {
+				b.WriteString(ellipsis)
+			}
+			continue
+		}
+
+		b.WriteRune(r)
+		cols += rw
+	}
+
+	return b.String()
+}
+
+// truncateStartWidth shortens the plain text s to at most w columns by removing its start, replaced by an
+// ellipsis, keeping its end visible.
+func truncateStartWidth(s string, w int) string {
+	if DisplayWidth(s) <= w {
+		return s
+	}
+
+	runes := []rune(s)
+	cols := 0
+	i := len(runes)
+	for i > 0 && cols+runeWidth(runes[i-1]) <= w-1 {
+		cols += runeWidth(runes[i-1])
+		i--
+	}
+
+	if w <= 0 {
+		return ""
+	}
+	return ellipsis + string(runes[i:])
+}
@@ -0,0 +1,100 @@
+package promptui
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{"plain text", "label", 5},
+		{"wide runes", "日本語", 6},
+		{"combining marks", "e\u0301", 1},
+		{"styled text", Styler(FGBold, FGRed)("label"), 5},
+		{"styled wide runes", "id " + Styler(FGCyan)("日本") + " ok", 10},
+		{"cursor codes", "\033[?25l" + clearLine + "a", 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if w := DisplayWidth(tc.input); w != tc.expected {
+				t.Errorf("expected %d columns, got %d", tc.expected, w)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain text", "日本語 label", "日本語 label"},
+		{"styled text", Styler(FGBold, FGRed)("label") + ": " + Styler(FGFaint)("hint"), "label: hint"},
+		{"cursor codes", hideCursor + "\r" + clearLine + "a" + upLine(2) + clearScreenDown + showCursor, "\ra"},
+		{"unterminated code", "a\033[3", "a"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if s := StripANSI(tc.input); s != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, s)
+			}
+		})
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{"short text", "label", 10, "label"},
+		{"exact width", "label", 5, "label"},
+		{"long text", "a long label", 6, "a lon…"},
+		{"wide runes", "日本語のラベル", 6, "日本…"},
+		{"styled text", Styler(FGBold)("a long label"), 6, "\033[1ma lon…\033[0m"},
+		{"styled suffix", "label " + Styler(FGRed)("error"), 4, "lab…\033[31m\033[0m"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := truncateWidth(tc.input, tc.width)
+			if result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+			if w := DisplayWidth(result); w > tc.width {
+				t.Errorf("expected at most %d columns, got %d", tc.width, w)
+			}
+		})
+	}
+}
+
+func TestTruncateStartWidth(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{"short text", "/tmp", 10, "/tmp"},
+		{"long path", "/home/user/projects/app", 8, "…cts/app"},
+		{"wide runes", "日本語のパス", 7, "…のパス"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := truncateStartWidth(tc.input, tc.width)
+			if result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+			if w := DisplayWidth(result); w > tc.width {
+				t.Errorf("expected at most %d columns, got %d", tc.width, w)
+			}
+		})
+	}
+}